@@ -1,30 +1,57 @@
 package libaduk
 
 import (
+	"errors"
 	"fmt"
-	"log"
 )
 
+var ErrSuicide = errors.New("Invalid move (Suicide not allowed)!")
+
 // Represents a Go board data structure
 type AbstractBoard struct {
-	BoardSize uint8
-	data      []BoardStatus
-	undoStack []*Move
-	zobrist   *ZobristHash
+	BoardSize  uint8
+	data       []BoardStatus
+	undoStack  []*Move
+	zobrist    *ZobristHash
+	rules      Ruleset
+	history    []positionHistory // Position after every undostack entry
+	positions  map[uint64]int    // Number of occurences of every position
+	situations map[situation]int // Number of occurences of every position with the last mover
+	handicap   int               // Number of handicap stones, they are the first undostack entries
+	prisoners  map[BoardStatus]int
+
+	// Incrementally maintained chains, see chain.go
+	chainHead  []int   // Index of the first stone of the chain for every stone, -1 for empty positions
+	chainNext  []int   // Index of the next stone of the chain (circular list)
+	chainLibs  []int   // Pseudo liberties (empty neighbours of every stone) of the chain, valid for heads
+	chainSize  []int   // Number of stones of the chain, valid for heads
+	neighbours [][]int // Neighbour indexes for every position
+	trail      []trailEntry
 }
 
-// Creates new Go Board
+// Creates new Go Board with japanese rules
 func NewBoard(boardSize uint8) (*AbstractBoard, error) {
+	return NewBoardWithRules(boardSize, JapaneseRules)
+}
+
+// Creates new Go Board that follows the given rules
+func NewBoardWithRules(boardSize uint8, rules Ruleset) (*AbstractBoard, error) {
 	if boardSize < 1 {
 		return nil, fmt.Errorf("Boardsize can not be less than 1!")
 	}
 
-	return &AbstractBoard{
-		boardSize,
-		make([]BoardStatus, boardSize*boardSize),
-		make([]*Move, 0),
-		NewZobristHash(boardSize),
-	}, nil
+	board := &AbstractBoard{
+		BoardSize: boardSize,
+		data:      make([]BoardStatus, int(boardSize)*int(boardSize)),
+		undoStack: make([]*Move, 0),
+		zobrist:   NewZobristHash(boardSize),
+		rules:     rules,
+		prisoners: map[BoardStatus]int{BLACK: 0, WHITE: 0},
+	}
+	board.initChains()
+	board.resetHistory()
+
+	return board, nil
 }
 
 // Returns a string representation of the current board status
@@ -55,6 +82,10 @@ func (board *AbstractBoard) Clear() {
 	}
 	board.zobrist.hash = 0
 	board.undoStack = []*Move{}
+	board.handicap = 0
+	board.prisoners = map[BoardStatus]int{BLACK: 0, WHITE: 0}
+	board.initChains()
+	board.resetHistory()
 }
 
 // Returns the Top Move of the Undostack
@@ -67,6 +98,8 @@ func (board *AbstractBoard) UndostackPop() (move *Move) {
 	if len(board.undoStack) > 0 {
 		move = board.undoStack[len(board.undoStack)-1]
 		board.undoStack = board.undoStack[:len(board.undoStack)-1]
+		board.popHistory()
+		board.countCaptures(move, -1)
 	}
 
 	return
@@ -75,11 +108,13 @@ func (board *AbstractBoard) UndostackPop() (move *Move) {
 // Adds the given Move to the Undostack
 func (board *AbstractBoard) UndostackPush(move *Move) {
 	board.undoStack = append(board.undoStack, move)
+	board.pushHistory(move)
+	board.countCaptures(move, 1)
 }
 
 // Adds a Pass to the Undostack
 func (board *AbstractBoard) UndostackPushPass() {
-	board.UndostackPush(&Move{255, 255, PASS, nil})
+	board.UndostackPush(&Move{X: 255, Y: 255, Color: PASS})
 }
 
 // Undo `count` moves on the board
@@ -88,6 +123,28 @@ func (board *AbstractBoard) Undo(count int) {
 		if len(board.undoStack) > 0 {
 			move := board.UndostackPop()
 
+			// Taking back handicap stones reduces the handicap
+			if len(board.undoStack) < board.handicap {
+				board.handicap = len(board.undoStack)
+			}
+
+			// Restore the status of setup positions
+			if move.Setup {
+				board.restoreSetup(move)
+				continue
+			}
+
+			// Add own stones of a suicide back to the board, the played stone is removed afterwards
+			if move.Suicide {
+				for _, capture := range move.Captures {
+					board.zobrist.Hash(capture.X, capture.Y, move.Color)
+					board.setStatus(capture.X, capture.Y, move.Color)
+				}
+				board.zobrist.Hash(move.X, move.Y, move.Color)
+				board.setStatus(move.X, move.Y, EMPTY)
+				continue
+			}
+
 			// Remove stone from the board and update hash
 			if move.Color == BLACK || move.Color == WHITE {
 				board.zobrist.Hash(move.X, move.Y, move.Color)
@@ -104,159 +161,110 @@ func (board *AbstractBoard) Undo(count int) {
 }
 
 // Returns current board hash value
-func (board *AbstractBoard) GetHash() int64 {
+func (board *AbstractBoard) GetHash() uint64 {
 	return board.zobrist.GetHash()
 }
 
 // Play move on board
 func (board *AbstractBoard) PlayMove(move Move) error {
+	if move.IsPass() {
+		board.UndostackPushPass()
+		return nil
+	}
+
 	return board.Play(move.X, move.Y, move.Color)
 }
 
-// Play stone at given position
-func (board *AbstractBoard) Play(x uint8, y uint8, color BoardStatus) error {
-	log.Printf("Play: X: %v, Y: %v, Color: %v", x, y, color)
-
-	// Is move on the board?
-	if x < 0 || x >= board.BoardSize || y < 0 || y >= board.BoardSize {
-		return fmt.Errorf("Invalid move position!")
+// Set the given position to status without checking for captures (e.g. for AB/AW/AE setup properties)
+func (board *AbstractBoard) Setup(x uint8, y uint8, status BoardStatus) error {
+	if x >= board.BoardSize || y >= board.BoardSize {
+		return fmt.Errorf("Invalid setup position!")
 	}
 
-	// Is already a stone on this position?
-	if board.getStatus(x, y) != EMPTY {
-		return fmt.Errorf("Position already occupied!")
+	if status != EMPTY && status != BLACK && status != WHITE {
+		return fmt.Errorf("Invalid setup status (%d)!", status)
 	}
 
-	// Check if move is legal and get captures
-	captures, err := board.legal(x, y, color)
-	if err != nil {
-		return err
+	previous := board.getStatus(x, y)
+	if previous != EMPTY {
+		board.zobrist.Hash(x, y, previous)
 	}
+	if status != EMPTY {
+		board.zobrist.Hash(x, y, status)
+	}
+	board.setStatus(x, y, status)
 
-	// Remove captures
-	for _, capture := range captures {
-		board.zobrist.Hash(capture.X, capture.Y, color.invert())
-		board.setStatus(capture.X, capture.Y, EMPTY)
+	// Adding a stone just joins the neighbour chains, everything else needs new chains
+	if previous == EMPTY && status != EMPTY {
+		board.addStone(board.index(x, y), status)
+	} else if previous != status {
+		board.rebuildChains()
 	}
 
-	// Add them to undostack
-	board.UndostackPush(&Move{x, y, color, captures})
+	board.UndostackPush(&Move{X: x, Y: y, Color: status, Setup: true, Replaced: previous})
 
 	return nil
 }
 
-// Checks if move is legal and returns captured stones if necessary
-func (board *AbstractBoard) legal(x uint8, y uint8, color BoardStatus) (captures []Position, err error) {
-	captures = []Position{}
-	neighbours := board.getNeighbours(x, y)
-
-	// Check if we capture neighbouring stones
-	for _, neighbour := range neighbours {
-		// Is neighbour from another color?
-		if board.getStatus(neighbour.X, neighbour.Y) == color.invert() {
-			// Get enemy stones with no liberties left
-			noLibertyStones := board.getNoLibertyStones(neighbour.X, neighbour.Y, Position{x, y})
-			for _, noLibertyStone := range noLibertyStones {
-				captures = append(captures, noLibertyStone)
-			}
-		}
+// Reverts a setup move
+func (board *AbstractBoard) restoreSetup(move *Move) {
+	if move.Color != EMPTY {
+		board.zobrist.Hash(move.X, move.Y, move.Color)
 	}
+	if move.Replaced != EMPTY {
+		board.zobrist.Hash(move.X, move.Y, move.Replaced)
+	}
+	board.setStatus(move.X, move.Y, move.Replaced)
+}
 
-	// Place stone on the board and update hash
-	board.zobrist.Hash(x, y, color)
-	board.setStatus(x, y, color)
+// Play stone at given position
+func (board *AbstractBoard) Play(x uint8, y uint8, color BoardStatus) error {
+	// Is move on the board?
+	if x >= board.BoardSize || y >= board.BoardSize {
+		return fmt.Errorf("Invalid move position!")
+	}
 
-	// TODO: Delete Duplicates necessary????
-	if len(captures) > 0 {
-		return
+	if color != BLACK && color != WHITE {
+		return fmt.Errorf("Invalid move color (%d)!", color)
 	}
 
-	// Check if the played move has no liberties and therefore is a suicide
-	selfNoLiberties := board.getNoLibertyStones(x, y, Position{})
+	// Is already a stone on this position?
+	if board.getStatus(x, y) != EMPTY {
+		return fmt.Errorf("Position already occupied!")
+	}
 
-	if len(selfNoLiberties) > 0 {
-		// Take move back
-		board.zobrist.Hash(x, y, color)
-		board.setStatus(x, y, EMPTY)
-		err = fmt.Errorf("Invalid move (Suicide not allowed)!")
+	// Place stone, remove captures and check for suicide
+	trailStart := len(board.trail)
+	captures, suicide, err := board.placeStone(board.index(x, y), color)
+	if err != nil {
+		return err
 	}
 
-	log.SetPrefix("")
-	return
-}
+	// Captures of a suicide are our own stones
+	capturedColor := color.invert()
+	koCaptures := captures
+	if suicide {
+		capturedColor = color
+		koCaptures = nil
+	}
 
-// Get all stones with no liberties left on given position
-func (board *AbstractBoard) getNoLibertyStones(x uint8, y uint8, orgPosition Position) (noLibertyStones []Position) {
-	log.Printf("Get no liberty stones for (%d, %d)", x, y)
-
-	noLibertyStones = []Position{}
-	newlyFoundStones := []Position{Position{x, y}}
-	foundNew := true
-	var groupStones []Position = nil
-
-	// Search until no new stones are found
-	for foundNew == true {
-		foundNew = false
-		groupStones = []Position{}
-
-		for _, newlyFoundStone := range newlyFoundStones {
-			neighbours := board.getNeighbours(newlyFoundStone.X, newlyFoundStone.Y)
-
-			// Check liberties of stone newlyFoundStone.X, newlyFoundStone.Y by checking the neighbours
-			for _, neighbour := range neighbours {
-				nbX := neighbour.X
-				nbY := neighbour.Y
-
-				// Has newlyFoundStone a free liberty?
-				if board.getStatus(nbX, nbY) == EMPTY && !neighbour.isSamePosition(orgPosition) {
-					// Neighbour is empty and not origPosition so newlyFoundStone has at least one liberty
-					return noLibertyStones[:0]
-				} else {
-					// Is the neighbour of newlyFoundStone.X, newlyFoundStone.Y the same color? Then we have a group here
-					if board.getStatus(newlyFoundStone.X, newlyFoundStone.Y) == board.getStatus(nbX, nbY) {
-						foundNewHere := true
-						nbGroupStone := Position{nbX, nbY}
-
-						log.Printf("Found group stone for (%d, %d) at %+v", newlyFoundStone.X, newlyFoundStone.Y, nbGroupStone)
-
-						// Check if found stone is already in our group list
-						for _, groupStone := range groupStones {
-							if groupStone.isSamePosition(nbGroupStone) {
-								foundNewHere = false
-								break
-							}
-						}
-
-						// Check if found stone is already in result set list
-						if foundNewHere {
-							for _, noLibertyStone := range noLibertyStones {
-								if noLibertyStone.isSamePosition(nbGroupStone) {
-									foundNewHere = false
-									break
-								}
-							}
-						}
-
-						// If groupStone is not known yet, add it
-						if foundNewHere {
-							groupStones = append(groupStones, nbGroupStone)
-							foundNew = true
-						}
-					}
-				}
-			}
+	// Take the move back if it repeats a forbidden position
+	if err := board.checkKo(color, koCaptures); err != nil {
+		for _, capture := range captures {
+			board.zobrist.Hash(capture.X, capture.Y, capturedColor)
+			board.setStatus(capture.X, capture.Y, capturedColor)
 		}
+		board.zobrist.Hash(x, y, color)
+		board.setStatus(x, y, EMPTY)
+		board.rollbackTrail(trailStart)
 
-		// Add newly found stones to the resultset
-		noLibertyStones = append(noLibertyStones, newlyFoundStones...)
-
-		// Now check the found group stones
-		newlyFoundStones = groupStones
+		return err
 	}
 
-	log.Printf("Found these stones with no liberties: %+v", noLibertyStones)
+	// Add them to undostack
+	board.UndostackPush(&Move{X: x, Y: y, Color: color, Captures: captures, Suicide: suicide})
 
-	return
+	return nil
 }
 
 // Returns the neighbour array positions for a given point
@@ -281,9 +289,25 @@ func (board *AbstractBoard) getNeighbours(x uint8, y uint8) (neighbourIndexes []
 }
 
 func (board *AbstractBoard) getStatus(x uint8, y uint8) BoardStatus {
-	return board.data[board.BoardSize*x+y]
+	return board.data[board.index(x, y)]
 }
 
 func (board *AbstractBoard) setStatus(x uint8, y uint8, status BoardStatus) {
-	board.data[board.BoardSize*x+y] = status
+	board.data[board.index(x, y)] = status
+}
+
+// Returns the data index of the given position
+func (board *AbstractBoard) index(x uint8, y uint8) int {
+	return int(board.BoardSize)*int(x) + int(y)
+}
+
+// Checks if position is part of positions
+func containsPosition(positions []Position, position Position) bool {
+	for _, p := range positions {
+		if p.isSamePosition(position) {
+			return true
+		}
+	}
+
+	return false
 }
@@ -0,0 +1,317 @@
+package libaduk
+
+// A chain of connected stones of the same color
+type Chain struct {
+	Color     BoardStatus
+	Stones    []Position
+	Liberties []Position
+}
+
+// Checks if the chain has only one liberty left
+func (chain *Chain) InAtari() bool {
+	return len(chain.Liberties) == 1
+}
+
+// Returns the status of the given position, positions off the board are EMPTY
+func (board *AbstractBoard) StoneAt(position Position) BoardStatus {
+	if !board.onBoard(position) {
+		return EMPTY
+	}
+
+	return board.getStatus(position.X, position.Y)
+}
+
+// Returns the neighbour positions of the given position that are on the board
+func (board *AbstractBoard) Neighbours(position Position) []Position {
+	if !board.onBoard(position) {
+		return nil
+	}
+
+	return board.getNeighbours(position.X, position.Y)
+}
+
+// Returns all stones of the chain at the given position or nil if there is no stone
+func (board *AbstractBoard) Group(position Position) []Position {
+	chain := board.chainAt(position)
+	if chain == nil {
+		return nil
+	}
+
+	return chain.Stones
+}
+
+// Returns the liberties of the chain at the given position or nil if there is no stone
+func (board *AbstractBoard) Liberties(position Position) []Position {
+	chain := board.chainAt(position)
+	if chain == nil {
+		return nil
+	}
+
+	return chain.Liberties
+}
+
+// Returns the number of liberties of the chain at the given position
+func (board *AbstractBoard) LibertyCount(position Position) int {
+	return len(board.Liberties(position))
+}
+
+// Returns all chains on the board
+func (board *AbstractBoard) Groups() []Chain {
+	chains := []Chain{}
+
+	for index, head := range board.chainHead {
+		if head == index {
+			chains = append(chains, *board.chain(head))
+		}
+	}
+
+	return chains
+}
+
+// Returns the chain at the given position or nil if there is no stone
+func (board *AbstractBoard) chainAt(position Position) *Chain {
+	if board.StoneAt(position) == EMPTY {
+		return nil
+	}
+
+	return board.chain(board.chainHead[board.index(position.X, position.Y)])
+}
+
+// Collects stones and liberties of the chain with the given head
+func (board *AbstractBoard) chain(head int) *Chain {
+	chain := &Chain{Color: board.data[head], Stones: make([]Position, 0, board.chainSize[head]), Liberties: []Position{}}
+	liberties := map[int]bool{}
+
+	for stone := head; ; {
+		chain.Stones = append(chain.Stones, board.position(stone))
+
+		for _, neighbour := range board.neighbours[stone] {
+			if board.data[neighbour] == EMPTY && !liberties[neighbour] {
+				liberties[neighbour] = true
+				chain.Liberties = append(chain.Liberties, board.position(neighbour))
+			}
+		}
+
+		if stone = board.chainNext[stone]; stone == head {
+			break
+		}
+	}
+
+	return chain
+}
+
+// A change of the chain data that can be taken back
+type trailEntry struct {
+	values []int
+	index  int
+	old    int
+}
+
+// Creates empty chains and the neighbour table
+func (board *AbstractBoard) initChains() {
+	size := len(board.data)
+
+	board.chainHead = make([]int, size)
+	board.chainNext = make([]int, size)
+	board.chainLibs = make([]int, size)
+	board.chainSize = make([]int, size)
+	board.trail = []trailEntry{}
+
+	for i := range board.chainHead {
+		board.chainHead[i] = -1
+		board.chainNext[i] = -1
+	}
+
+	if len(board.neighbours) != size {
+		board.neighbours = make([][]int, size)
+		for i := range board.neighbours {
+			position := board.position(i)
+			for _, neighbour := range board.getNeighbours(position.X, position.Y) {
+				board.neighbours[i] = append(board.neighbours[i], board.index(neighbour.X, neighbour.Y))
+			}
+		}
+	}
+}
+
+// Changes a chain value and remembers the old one on the trail
+func (board *AbstractBoard) setChainValue(values []int, index int, value int) {
+	if values[index] != value {
+		board.trail = append(board.trail, trailEntry{values, index, values[index]})
+		values[index] = value
+	}
+}
+
+// Takes back all chain changes until the trail has the given length
+func (board *AbstractBoard) rollbackTrail(length int) {
+	for i := len(board.trail) - 1; i >= length; i-- {
+		entry := board.trail[i]
+		entry.values[entry.index] = entry.old
+	}
+
+	board.trail = board.trail[:length]
+}
+
+// Places a stone, removes captured chains and checks for suicide. A suicide that is
+// not allowed is taken back, an allowed one returns the own removed stones as captures
+func (board *AbstractBoard) placeStone(index int, color BoardStatus) (captures []Position, suicide bool, err error) {
+	trailStart := len(board.trail)
+	position := board.position(index)
+
+	board.zobrist.Hash(position.X, position.Y, color)
+	board.data[index] = color
+	board.addStone(index, color)
+
+	// Remove neighbouring enemy chains without liberties left
+	captures = []Position{}
+	for _, neighbour := range board.neighbours[index] {
+		if board.data[neighbour] == color.invert() && board.chainLibs[board.chainHead[neighbour]] == 0 {
+			captures = append(captures, board.removeChain(board.chainHead[neighbour])...)
+		}
+	}
+
+	if len(captures) > 0 || board.chainLibs[board.chainHead[index]] > 0 {
+		return captures, false, nil
+	}
+
+	if board.rules.SuicideAllowed {
+		return board.removeChain(board.chainHead[index]), true, nil
+	}
+
+	// Take move back
+	board.zobrist.Hash(position.X, position.Y, color)
+	board.data[index] = EMPTY
+	board.rollbackTrail(trailStart)
+
+	return nil, false, ErrSuicide
+}
+
+// Adds the stone at index (already set in data) to the chains without removing captures
+func (board *AbstractBoard) addStone(index int, color BoardStatus) {
+	liberties := 0
+	for _, neighbour := range board.neighbours[index] {
+		if board.data[neighbour] == EMPTY {
+			liberties++
+		} else {
+			// The neighbour chain looses the liberty we play on
+			head := board.chainHead[neighbour]
+			board.setChainValue(board.chainLibs, head, board.chainLibs[head]-1)
+		}
+	}
+
+	board.setChainValue(board.chainHead, index, index)
+	board.setChainValue(board.chainNext, index, index)
+	board.setChainValue(board.chainSize, index, 1)
+	board.setChainValue(board.chainLibs, index, liberties)
+
+	for _, neighbour := range board.neighbours[index] {
+		if board.data[neighbour] == color {
+			board.mergeChains(board.chainHead[index], board.chainHead[neighbour])
+		}
+	}
+}
+
+// Merges two chains, the stones of the smaller chain get the head of the larger one
+func (board *AbstractBoard) mergeChains(a int, b int) {
+	if a == b {
+		return
+	}
+
+	if board.chainSize[a] < board.chainSize[b] {
+		a, b = b, a
+	}
+
+	for stone := b; ; {
+		board.setChainValue(board.chainHead, stone, a)
+		if stone = board.chainNext[stone]; stone == b {
+			break
+		}
+	}
+
+	// Join both circular lists
+	nextA, nextB := board.chainNext[a], board.chainNext[b]
+	board.setChainValue(board.chainNext, a, nextB)
+	board.setChainValue(board.chainNext, b, nextA)
+
+	board.setChainValue(board.chainSize, a, board.chainSize[a]+board.chainSize[b])
+	board.setChainValue(board.chainLibs, a, board.chainLibs[a]+board.chainLibs[b])
+}
+
+// Removes all stones of the chain from the board and returns their positions
+func (board *AbstractBoard) removeChain(head int) []Position {
+	color := board.data[head]
+	stones := make([]int, 0, board.chainSize[head])
+
+	for stone := head; ; {
+		stones = append(stones, stone)
+		if stone = board.chainNext[stone]; stone == head {
+			break
+		}
+	}
+
+	removed := make([]Position, len(stones))
+	for i, stone := range stones {
+		removed[i] = board.position(stone)
+		board.zobrist.Hash(removed[i].X, removed[i].Y, color)
+		board.data[stone] = EMPTY
+		board.setChainValue(board.chainHead, stone, -1)
+	}
+
+	// Every removed stone is a new liberty for the neighbouring chains
+	for _, stone := range stones {
+		for _, neighbour := range board.neighbours[stone] {
+			if head := board.chainHead[neighbour]; head != -1 {
+				board.setChainValue(board.chainLibs, head, board.chainLibs[head]+1)
+			}
+		}
+	}
+
+	return removed
+}
+
+// Builds all chains again from the board data
+func (board *AbstractBoard) rebuildChains() {
+	for index := range board.data {
+		board.setChainValue(board.chainHead, index, -1)
+	}
+
+	for index, color := range board.data {
+		if color != EMPTY && board.chainHead[index] == -1 {
+			board.setChainValue(board.chainHead, index, index)
+			board.collectChain(index, color)
+		}
+	}
+}
+
+// Sets head, next, size and liberties for the chain starting at head
+func (board *AbstractBoard) collectChain(head int, color BoardStatus) {
+	stones := []int{head}
+	liberties := 0
+
+	for i := 0; i < len(stones); i++ {
+		for _, neighbour := range board.neighbours[stones[i]] {
+			if board.data[neighbour] == EMPTY {
+				liberties++
+			} else if board.data[neighbour] == color && board.chainHead[neighbour] == -1 {
+				board.setChainValue(board.chainHead, neighbour, head)
+				stones = append(stones, neighbour)
+			}
+		}
+	}
+
+	for i, stone := range stones {
+		board.setChainValue(board.chainNext, stone, stones[(i+1)%len(stones)])
+	}
+
+	board.setChainValue(board.chainSize, head, len(stones))
+	board.setChainValue(board.chainLibs, head, liberties)
+}
+
+// Returns the position of the given data index
+func (board *AbstractBoard) position(index int) Position {
+	return Position{uint8(index / int(board.BoardSize)), uint8(index % int(board.BoardSize))}
+}
+
+// Checks if the position is on the board
+func (board *AbstractBoard) onBoard(position Position) bool {
+	return position.X < board.BoardSize && position.Y < board.BoardSize
+}
@@ -0,0 +1,227 @@
+package libaduk
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+// Tests group and liberty inspection
+func TestGroupAndLiberties(t *testing.T) {
+	board, _ := NewBoard(9)
+
+	board.Play(0, 0, BLACK)
+	board.Play(0, 1, BLACK)
+	board.Play(1, 0, WHITE)
+	board.Play(4, 4, WHITE)
+
+	if board.StoneAt(Position{0, 1}) != BLACK || board.StoneAt(Position{9, 9}) != EMPTY {
+		t.Errorf("StoneAt returned wrong status!")
+	}
+
+	group := board.Group(Position{0, 0})
+	if len(group) != 2 || !containsPosition(group, Position{0, 1}) {
+		t.Errorf("Group should contain both black stones but was %+v", group)
+	}
+
+	liberties := board.Liberties(Position{0, 1})
+	if len(liberties) != 2 || !containsPosition(liberties, Position{1, 1}) || !containsPosition(liberties, Position{0, 2}) {
+		t.Errorf("Black group should have liberties (1, 1) and (0, 2) but was %+v", liberties)
+	}
+
+	if board.LibertyCount(Position{1, 0}) != 2 || board.LibertyCount(Position{5, 5}) != 0 {
+		t.Errorf("Wrong liberty count!")
+	}
+
+	if len(board.Neighbours(Position{0, 0})) != 2 || len(board.Neighbours(Position{4, 4})) != 4 {
+		t.Errorf("Wrong number of neighbours!")
+	}
+}
+
+// Tests if all chains are found
+func TestGroups(t *testing.T) {
+	board, _ := NewBoard(5)
+
+	board.Play(0, 0, BLACK)
+	board.Play(0, 1, BLACK)
+	board.Play(1, 0, WHITE)
+	board.Play(1, 1, WHITE)
+	board.Play(4, 4, BLACK)
+
+	chains := board.Groups()
+	if len(chains) != 3 {
+		t.Fatalf("Board should have 3 chains but had %+v", chains)
+	}
+
+	for _, chain := range chains {
+		if chain.Color == BLACK && len(chain.Stones) == 2 && !chain.InAtari() {
+			t.Errorf("Black corner chain should be in atari but was %+v", chain)
+		}
+		if chain.Color == WHITE && (len(chain.Stones) != 2 || len(chain.Liberties) != 3) {
+			t.Errorf("White chain should have 2 stones and 3 liberties but was %+v", chain)
+		}
+	}
+}
+
+// Calls visit for every node of the tree starting at root after the node was played on board.
+// Nodes that can't be played are skipped together with their children
+func replayTree(board *AbstractBoard, root *Node, visit func(node *Node)) (skipped int) {
+	type step struct {
+		node  *Node
+		undo  int
+		ended bool
+	}
+
+	stack := []step{{node: root}}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+
+		if top.ended {
+			board.Undo(top.undo)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		undo, err := applyNode(board, top.node)
+		top.ended, top.undo = true, undo
+		if err != nil {
+			skipped++
+			continue
+		}
+
+		if visit != nil {
+			visit(top.node)
+		}
+
+		for child := top.node.Next; child != nil; child = child.Down {
+			stack = append(stack, step{node: child})
+		}
+	}
+
+	return
+}
+
+// Checks if the incremental chains are the same as chains found by flood fill
+func checkChains(t *testing.T, board *AbstractBoard) bool {
+	for index, color := range board.data {
+		if color == EMPTY {
+			if board.chainHead[index] != -1 {
+				t.Errorf("Empty position %d has chain head %d", index, board.chainHead[index])
+				return false
+			}
+			continue
+		}
+
+		head := board.chainHead[index]
+		if head == -1 || board.data[head] != color {
+			t.Errorf("Stone %d has invalid head %d", index, head)
+			return false
+		}
+
+		// Compare with flood fill
+		stones := map[int]bool{}
+		pseudoLiberties := 0
+		stack := []int{index}
+		stones[index] = true
+		for len(stack) > 0 {
+			stone := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, neighbour := range board.neighbours[stone] {
+				if board.data[neighbour] == EMPTY {
+					pseudoLiberties++
+				} else if board.data[neighbour] == color && !stones[neighbour] {
+					stones[neighbour] = true
+					stack = append(stack, neighbour)
+				}
+			}
+		}
+
+		count := 0
+		for stone := head; ; stone = board.chainNext[stone] {
+			if !stones[stone] || board.chainHead[stone] != head || count > len(stones) {
+				t.Errorf("Chain list of %d contains invalid stone %d", head, stone)
+				return false
+			}
+			count++
+			if board.chainNext[stone] == head {
+				break
+			}
+		}
+
+		if count != len(stones) || board.chainSize[head] != count || board.chainLibs[head] != pseudoLiberties {
+			t.Errorf("Chain %d should have %d stones and %d pseudo liberties but had %d (%d) and %d",
+				head, len(stones), pseudoLiberties, count, board.chainSize[head], board.chainLibs[head])
+			return false
+		}
+	}
+
+	return true
+}
+
+// Tests if the chains stay correct while replaying and undoing all variations of the test games
+func TestChainsReplayTestGames(t *testing.T) {
+	for _, file := range []string{Testgame9x9, TestgameSmall, TestgameKogo} {
+		sgfData, _ := ioutil.ReadFile(file)
+		cursor, _ := NewCursor(sgfData)
+		boardSize, _ := cursor.rootNode.BoardSize()
+		board, _ := NewBoard(boardSize)
+		valid := true
+
+		replayTree(board, cursor.rootNode, func(node *Node) {
+			if valid && len(board.undoStack)%7 == 0 {
+				valid = checkChains(t, board)
+			}
+		})
+
+		if len(board.undoStack) != 0 || board.GetHash() != 0 || !checkChains(t, board) {
+			t.Errorf("Board of %s should be empty after replay but was:\n%s", file, board.ToString())
+		}
+	}
+}
+
+// Tests if setup positions keep the chains correct
+func TestChainsSetup(t *testing.T) {
+	board, _ := NewBoard(5)
+
+	board.Setup(1, 1, BLACK)
+	board.Setup(1, 2, BLACK)
+	board.Setup(1, 3, BLACK)
+	board.Setup(1, 2, EMPTY)
+	board.Setup(2, 2, WHITE)
+	checkChains(t, board)
+
+	if len(board.Group(Position{1, 1})) != 1 {
+		t.Errorf("Removing the middle stone should split the chain but was %+v", board.Group(Position{1, 1}))
+	}
+
+	board.Undo(2)
+	checkChains(t, board)
+
+	if len(board.Group(Position{1, 1})) != 3 {
+		t.Errorf("Undo should join the chain again but was %+v", board.Group(Position{1, 1}))
+	}
+}
+
+// Benchmarks replaying all variations of the given sgf file
+func benchmarkReplay(b *testing.B, file string) {
+	sgfData, _ := ioutil.ReadFile(file)
+	cursor, _ := NewCursor(sgfData)
+	boardSize, _ := cursor.rootNode.BoardSize()
+	board, _ := NewBoard(boardSize)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		replayTree(board, cursor.rootNode, nil)
+	}
+}
+
+func BenchmarkReplay9x9(b *testing.B) {
+	benchmarkReplay(b, Testgame9x9)
+}
+
+func BenchmarkReplaySmall(b *testing.B) {
+	benchmarkReplay(b, TestgameSmall)
+}
+
+func BenchmarkReplayKogo(b *testing.B) {
+	benchmarkReplay(b, TestgameKogo)
+}
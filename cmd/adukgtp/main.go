@@ -0,0 +1,47 @@
+// Command adukgtp runs libaduk as GTP engine that plays random legal moves
+//
+// Usage:
+//
+//	adukgtp
+//
+// The engine reads GTP commands from stdin and writes the responses to stdout,
+// so it can be added as engine to GUIs like Sabaki or GoGui.
+package main
+
+import (
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/Beldur/libaduk"
+)
+
+// Plays a random legal move or passes if there is none
+type randomGenerator struct {
+	rnd *rand.Rand
+}
+
+func (generator *randomGenerator) GenerateMove(board *libaduk.AbstractBoard, color libaduk.BoardStatus) (libaduk.Move, error) {
+	size := int(board.BoardSize)
+
+	for _, index := range generator.rnd.Perm(size * size) {
+		x, y := uint8(index/size), uint8(index%size)
+
+		// Try the move and take it back, we just want to know if it's legal
+		if err := board.Play(x, y, color); err == nil {
+			board.Undo(1)
+			return libaduk.Move{X: x, Y: y, Color: color}, nil
+		}
+	}
+
+	return libaduk.Move{X: 255, Y: 255, Color: color}, nil
+}
+
+func main() {
+	generator := &randomGenerator{rand.New(rand.NewSource(time.Now().UnixNano()))}
+	server := libaduk.NewGTPServer("libaduk", "0.1", generator)
+
+	if err := server.Serve(os.Stdin, os.Stdout); err != nil {
+		os.Exit(1)
+	}
+}
@@ -2,6 +2,7 @@ package libaduk
 
 import (
 	"fmt"
+	"io"
 )
 
 // The cursor provides methods to traverse the game
@@ -9,6 +10,12 @@ type Cursor struct {
 	rootNode *Node
 	// Pointer to current Node in tree
 	currentNode *Node
+	// Board that is kept in sync with the current node (only for board cursors)
+	board *AbstractBoard
+	// Nodes from the root to the current node that are played on the board
+	boardPath []*Node
+	// Number of undostack entries every node of boardPath added to the board
+	boardUndo []int
 }
 
 // Create a new cursor struct for given sgf data
@@ -19,25 +26,36 @@ func NewCursor(sgf []byte) (*Cursor, error) {
 		return nil, err
 	}
 
-	return &Cursor{tree, tree}, nil
+	return &Cursor{rootNode: tree, currentNode: tree}, nil
 }
 
-// Returns the n'th root node. In a normal game there is only one root (0)
+// Returns the root node of the n'th game, the roots of a collection are linked as siblings
 func (cursor *Cursor) getRootNode(n int) (*Node, error) {
-	if n >= cursor.rootNode.numChildren {
-		return nil, fmt.Errorf("Cant find %d'th Root Node!", n)
-	}
-
-	node := cursor.rootNode.Next
+	node := cursor.rootNode
 
-	for i := 0; i < n; i++ {
+	for i := 0; i < n && node != nil; i++ {
 		node = node.Down
 	}
 
+	if n < 0 || node == nil {
+		return nil, fmt.Errorf("Can't find %d'th Game!", n)
+	}
+
 	return node, nil
 }
 
-// Set the Cursor to the n'th game
+// Returns the number of games in the collection
+func (cursor *Cursor) NumGames() int {
+	count := 0
+
+	for node := cursor.rootNode; node != nil; node = node.Down {
+		count++
+	}
+
+	return count
+}
+
+// Set the Cursor to the root node of the n'th game in the collection
 func (cursor *Cursor) Game(n int) (*Node, error) {
 	gameNode, err := cursor.getRootNode(n)
 
@@ -45,9 +63,7 @@ func (cursor *Cursor) Game(n int) (*Node, error) {
 		return nil, err
 	}
 
-	cursor.currentNode = gameNode
-
-	return cursor.currentNode, nil
+	return cursor.moveTo(gameNode)
 }
 
 // Returns the Cursors current node
@@ -61,13 +77,13 @@ func (cursor *Cursor) Next(n int) (*Node, error) {
 		return nil, fmt.Errorf("Can't find %d'th Next Node!", n)
 	}
 
-	cursor.currentNode = cursor.currentNode.Next
+	node := cursor.currentNode.Next
 
 	for i := 0; i < n; i++ {
-		cursor.currentNode = cursor.currentNode.Down
+		node = node.Down
 	}
 
-	return cursor.currentNode, nil
+	return cursor.moveTo(node)
 }
 
 // Set the cursor to the previous node
@@ -76,25 +92,49 @@ func (cursor *Cursor) Previous() (*Node, error) {
 		return nil, fmt.Errorf("Can't find Previous Node!")
 	}
 
-	cursor.currentNode = cursor.currentNode.Previous
+	return cursor.moveTo(cursor.currentNode.Previous)
+}
+
+// Set the cursor to the given node, the board is updated if the cursor has one
+func (cursor *Cursor) moveTo(node *Node) (*Node, error) {
+	if cursor.board != nil {
+		if err := cursor.syncBoard(node); err != nil {
+			return nil, err
+		}
+	}
+
+	cursor.currentNode = node
 
 	return cursor.currentNode, nil
 }
 
-// Deletes the given variation from the tree
+// Writes all games of the cursor as SGF collection to w
+func (cursor *Cursor) WriteSGF(w io.Writer) error {
+	roots := []*Node{}
+
+	for node := cursor.rootNode; node != nil; node = node.Down {
+		roots = append(roots, node)
+	}
+
+	return writeCollection(w, roots...)
+}
+
+// Deletes the given variation from the tree, for a root node all its children are deleted.
+// If the current node is deleted the cursor moves to the parent of the variation
 func (cursor *Cursor) DeleteVariation(node *Node) {
 	if node.Previous != nil {
-		cursor.removeNode(node)
-	} else {
-		if node.Next != nil {
-			n := node.Next
-			for n.Down != nil {
-				n = node.Down
-				cursor.removeNode(n.Up)
-			}
-			cursor.removeNode(n)
+		if isAncestor(node, cursor.currentNode) {
+			cursor.moveTo(node.Previous)
 		}
-		node.Next = nil
+		cursor.removeNode(node)
+		return
+	}
+
+	if isAncestor(node, cursor.currentNode) {
+		cursor.moveTo(node)
+	}
+	for node.Next != nil {
+		cursor.removeNode(node.Next)
 	}
 }
 
@@ -112,14 +152,23 @@ func (cursor *Cursor) removeNode(node *Node) {
 		node.Down.Up = node.Up
 
 		// Update levels for all Down nodes bei -1
-		n := node.Down
-		for n != nil {
+		for n := node.Down; n != nil; n = n.Down {
 			n.level--
-			n = node.Down
 		}
 	}
 
-	// Update children count of parent and destroy node
+	// Update children count of parent and unlink node
 	node.Previous.numChildren--
-	node = nil
+	node.Up, node.Down = nil, nil
+}
+
+// Checks if node is ancestor of descendant or the same node
+func isAncestor(node *Node, descendant *Node) bool {
+	for ; descendant != nil; descendant = descendant.Previous {
+		if descendant == node {
+			return true
+		}
+	}
+
+	return false
 }
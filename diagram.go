@@ -0,0 +1,328 @@
+package libaduk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Markup symbol on a board position
+type Mark uint8
+
+const (
+	NO_MARK  Mark = iota
+	TRIANGLE      // SGF TR
+	CIRCLE        // SGF CR
+	SQUARE        // SGF SQ
+	CROSS         // SGF MA
+)
+
+// Rectangular part of the board, From is the upper left and To the lower right corner (both included)
+type Region struct {
+	From Position
+	To   Position
+}
+
+// Connection of two positions drawn as arrow (SGF AR) or line (SGF LN)
+type diagramLine struct {
+	from  Position
+	to    Position
+	arrow bool
+}
+
+// Everything that is drawn for a position, used by the renderers
+type diagram struct {
+	size     uint8
+	stones   []BoardStatus // Indexed like the board data
+	labels   map[Position]string
+	marks    map[Position]Mark
+	lines    []diagramLine
+	lastMove *Position
+	region   Region
+	notes    []kifuNote // Moves that can't be shown on the board, e.g. moves played on occupied positions
+}
+
+// Move of a numbered diagram that is listed beside the board
+type kifuNote struct {
+	number   int
+	move     Move
+	atNumber int // Number of the stone on the occupied position, 0 if it was on the board before the diagram
+}
+
+// Returns the note as text, label converts move numbers to the numbers shown on the diagram.
+// Stones without number are written as vertex if vertices is set and the board has vertices
+func (note kifuNote) text(label func(int) string, vertices bool, boardSize uint8) string {
+	switch {
+	case note.move.IsPass():
+		return fmt.Sprintf("%s: %s pass", label(note.number), colorName(note.move.Color))
+	case note.atNumber > 0:
+		return fmt.Sprintf("%s at %s", label(note.number), label(note.atNumber))
+	case vertices && boardSize <= maxVertexBoardSize:
+		return fmt.Sprintf("%s at %s", label(note.number), VertexString(note.move, boardSize))
+	}
+
+	return fmt.Sprintf("%s at a stone already on the board", label(note.number))
+}
+
+// Returns the texts of all notes with the move numbers of the game and vertices
+func (d *diagram) noteTexts() []string {
+	texts := []string{}
+	for _, note := range d.notes {
+		texts = append(texts, note.text(strconv.Itoa, true, d.size))
+	}
+
+	return texts
+}
+
+// Creates a diagram of the current board position, the last move is marked
+func boardDiagram(board *AbstractBoard) *diagram {
+	d := &diagram{
+		size:   board.BoardSize,
+		stones: make([]BoardStatus, len(board.data)),
+		labels: map[Position]string{},
+		marks:  map[Position]Mark{},
+		region: Region{Position{0, 0}, Position{board.BoardSize - 1, board.BoardSize - 1}},
+	}
+	copy(d.stones, board.data)
+
+	for i := len(board.undoStack) - 1; i >= 0; i-- {
+		move := board.undoStack[i]
+		if move.Setup {
+			continue
+		}
+
+		if !move.IsPass() && board.getStatus(move.X, move.Y) == move.Color {
+			d.lastMove = &Position{move.X, move.Y}
+		}
+		break
+	}
+
+	return d
+}
+
+// Creates a diagram of the board at the current node of the cursor with the markup of the node
+func cursorDiagram(cursor *Cursor) (*diagram, error) {
+	if cursor.board == nil {
+		return nil, fmt.Errorf("Cursor has no board!")
+	}
+
+	d := boardDiagram(cursor.board)
+	d.lastMove = nil
+
+	move, err := cursor.currentNode.Move(d.size)
+	if err == nil && move != nil && !move.IsPass() && d.stoneAt(Position{move.X, move.Y}) == move.Color {
+		d.lastMove = &Position{move.X, move.Y}
+	}
+
+	return d, d.addMarkup(cursor.currentNode)
+}
+
+// Creates a numbered diagram of the moves from to to (including both) on the path to the current node of the cursor.
+// The diagram shows the position before move from, moves on occupied positions are listed as notes
+func kifuDiagram(cursor *Cursor, from int, to int) (*diagram, error) {
+	if from < 1 || to < from {
+		return nil, fmt.Errorf("Invalid move range %d-%d!", from, to)
+	}
+
+	path := pathToNode(cursor.currentNode)
+	board, err := newRootBoard(path[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var d *diagram
+	moveNumber := 0
+
+	for _, node := range path {
+		move, err := node.Move(board.BoardSize)
+		if err != nil {
+			return nil, err
+		}
+
+		if move != nil {
+			moveNumber++
+		}
+
+		if d == nil && (moveNumber < from || move == nil) {
+			if _, err := applyNode(board, node); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if d == nil {
+			d = boardDiagram(board)
+			d.lastMove = nil
+		}
+
+		// Setup comes before the move of the node
+		if err := d.addSetup(node); err != nil {
+			return nil, err
+		}
+
+		if move != nil {
+			d.addKifuMove(moveNumber, move)
+		}
+
+		if moveNumber == to && move != nil {
+			break
+		}
+	}
+
+	if d == nil {
+		return nil, fmt.Errorf("Move %d was not played!", from)
+	}
+
+	return d, nil
+}
+
+// Adds a numbered move to the diagram or a note if the position is already occupied
+func (d *diagram) addKifuMove(number int, move *Move) {
+	label := strconv.Itoa(number)
+	position := Position{move.X, move.Y}
+
+	if move.IsPass() {
+		d.notes = append(d.notes, kifuNote{number: number, move: *move})
+		return
+	}
+
+	if d.stoneAt(position) != EMPTY {
+		atNumber, _ := strconv.Atoi(d.labels[position])
+		d.notes = append(d.notes, kifuNote{number, *move, atNumber})
+		return
+	}
+
+	d.stones[int(d.size)*int(position.X)+int(position.Y)] = move.Color
+	d.labels[position] = label
+}
+
+// Changes the stones by the AE, AB and AW setup of the node, labels of changed positions are removed
+func (d *diagram) addSetup(node *Node) error {
+	for _, setup := range []struct {
+		identifier string
+		status     BoardStatus
+	}{{"AE", EMPTY}, {"AB", BLACK}, {"AW", WHITE}} {
+		values, ok := node.Property(setup.identifier)
+		if !ok {
+			continue
+		}
+
+		positions, err := parsePointList(values)
+		if err != nil {
+			return err
+		}
+
+		for _, position := range positions {
+			if position.X >= d.size || position.Y >= d.size {
+				return fmt.Errorf("Invalid setup position (%d, %d)!", position.X, position.Y)
+			}
+
+			d.stones[int(d.size)*int(position.X)+int(position.Y)] = setup.status
+			delete(d.labels, position)
+		}
+	}
+
+	return nil
+}
+
+// Adds the LB, TR, CR, SQ, MA, AR and LN markup of the node
+func (d *diagram) addMarkup(node *Node) error {
+	for _, mark := range []struct {
+		identifier string
+		mark       Mark
+	}{{"TR", TRIANGLE}, {"CR", CIRCLE}, {"SQ", SQUARE}, {"MA", CROSS}} {
+		values, ok := node.Property(mark.identifier)
+		if !ok {
+			continue
+		}
+
+		positions, err := parsePointList(values)
+		if err != nil {
+			return err
+		}
+
+		for _, position := range positions {
+			d.marks[position] = mark.mark
+		}
+	}
+
+	labels, _ := node.Property("LB")
+	for _, value := range labels {
+		if len(value) < 3 || value[2] != ':' {
+			return fmt.Errorf("Invalid label %q!", value)
+		}
+
+		position, err := parsePoint(value[:2])
+		if err != nil {
+			return err
+		}
+		d.labels[position] = value[3:]
+	}
+
+	for _, identifier := range []string{"AR", "LN"} {
+		values, _ := node.Property(identifier)
+		for _, value := range values {
+			parts := strings.Split(value, ":")
+			if len(parts) != 2 {
+				return fmt.Errorf("Invalid %s value %q!", identifier, value)
+			}
+
+			from, errFrom := parsePoint(parts[0])
+			to, errTo := parsePoint(parts[1])
+			if errFrom != nil || errTo != nil {
+				return fmt.Errorf("Invalid %s value %q!", identifier, value)
+			}
+
+			d.lines = append(d.lines, diagramLine{from, to, identifier == "AR"})
+		}
+	}
+
+	return nil
+}
+
+// Restricts the diagram to the region
+func (d *diagram) crop(region Region) error {
+	if region.From.X > region.To.X || region.From.Y > region.To.Y || region.To.X >= d.size || region.To.Y >= d.size {
+		return fmt.Errorf("Invalid region %+v!", region)
+	}
+
+	d.region = region
+
+	return nil
+}
+
+// Returns the stone at the position
+func (d *diagram) stoneAt(position Position) BoardStatus {
+	return d.stones[int(d.size)*int(position.X)+int(position.Y)]
+}
+
+// Checks if the position is inside the region of the diagram
+func (d *diagram) contains(position Position) bool {
+	return position.X >= d.region.From.X && position.X <= d.region.To.X &&
+		position.Y >= d.region.From.Y && position.Y <= d.region.To.Y
+}
+
+// Returns the star points of the board, small boards only have the corner and center points
+func (d *diagram) starPoints() []Position {
+	stones := 9
+	if d.size < 13 {
+		stones = 5
+	}
+
+	if positions, err := FixedHandicapPositions(d.size, stones); err == nil {
+		return positions
+	}
+
+	positions, _ := FixedHandicapPositions(d.size, 4)
+
+	return positions
+}
+
+// Returns the name of the color
+func colorName(color BoardStatus) string {
+	if color == WHITE {
+		return "White"
+	}
+
+	return "Black"
+}
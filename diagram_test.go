@@ -0,0 +1,108 @@
+package libaduk
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+// Game with a capture and a retake at the same position
+const kifuTestGame = "(;SZ[5];B[ba];W[ca];B[ab];W[db];B[bc];W[cc];B[cb];W[bb];B[ee];W[ed];B[cb];W[])"
+
+// Tests if the markup of the current node is added to the diagram
+func TestCursorDiagramMarkup(t *testing.T) {
+	cursor, _ := NewBoardCursor([]byte("(;SZ[9];B[cc];W[dc]LB[ee:A][ff:12]TR[aa]CR[bb]SQ[cb]MA[db:eb]AR[aa:ii]LN[ab:bb])"))
+	cursor.Next(0)
+	cursor.Next(0)
+
+	d, err := cursorDiagram(cursor)
+	if err != nil {
+		t.Fatalf("Diagram should be created but was %+v", err)
+	}
+
+	if d.labels[Position{4, 4}] != "A" || d.labels[Position{5, 5}] != "12" || d.marks[Position{0, 0}] != TRIANGLE || d.marks[Position{4, 1}] != CROSS {
+		t.Errorf("Labels and marks should be added but were %+v %+v", d.labels, d.marks)
+	}
+
+	if len(d.lines) != 2 || !d.lines[0].arrow || d.lines[1].arrow || d.lines[0].to != (Position{8, 8}) {
+		t.Errorf("Arrow and line should be added but were %+v", d.lines)
+	}
+
+	if d.lastMove == nil || *d.lastMove != (Position{3, 2}) || d.stoneAt(Position{2, 2}) != BLACK {
+		t.Errorf("Last move should be (3, 2) but was %+v", d.lastMove)
+	}
+
+	if err := d.crop(Region{Position{2, 2}, Position{1, 5}}); err == nil {
+		t.Errorf("Region should be invalid!")
+	}
+}
+
+// Tests numbered diagrams with moves on occupied positions
+func TestKifuDiagram(t *testing.T) {
+	cursor, _ := NewBoardCursor([]byte(kifuTestGame))
+	for i := 0; i < 12; i++ {
+		cursor.Next(0)
+	}
+
+	d, err := kifuDiagram(cursor, 8, 12)
+	if err != nil {
+		t.Fatalf("Kifu should be created but was %+v", err)
+	}
+
+	// Move 7 is shown without number, it was played before the diagram
+	if _, ok := d.labels[Position{2, 1}]; ok || d.stoneAt(Position{2, 1}) != BLACK {
+		t.Errorf("Stone of move 7 should be shown without label!")
+	}
+
+	if d.labels[Position{1, 1}] != "8" || d.labels[Position{4, 3}] != "10" || d.stoneAt(Position{1, 1}) != WHITE {
+		t.Errorf("Moves should be numbered but were %+v", d.labels)
+	}
+
+	if len(d.notes) != 2 || d.noteTexts()[0] != "11 at C4" || d.noteTexts()[1] != "12: White pass" {
+		t.Errorf("Notes should list the retake and the pass but were %+v", d.noteTexts())
+	}
+
+	d, _ = kifuDiagram(cursor, 1, 11)
+	if len(d.notes) != 1 || d.noteTexts()[0] != "11 at 7" {
+		t.Errorf("Retake should refer to move 7 but was %+v", d.noteTexts())
+	}
+
+	if _, err := kifuDiagram(cursor, 13, 20); err == nil {
+		t.Errorf("Kifu of moves that were not played should fail!")
+	}
+}
+
+// Tests if setup inside the move range changes the stones of the diagram
+func TestKifuDiagramSetup(t *testing.T) {
+	cursor, _ := NewBoardCursor([]byte("(;SZ[9];B[aa];W[bb];AE[aa]AB[cc];B[dd]AW[ee];W[ff])"))
+	for i := 0; i < 5; i++ {
+		cursor.Next(0)
+	}
+
+	d, err := kifuDiagram(cursor, 1, 3)
+	if err != nil {
+		t.Fatalf("Kifu should be created but was %+v", err)
+	}
+
+	if d.stoneAt(Position{0, 0}) != EMPTY || d.labels[Position{0, 0}] != "" || d.stoneAt(Position{2, 2}) != BLACK || d.stoneAt(Position{4, 4}) != WHITE {
+		t.Errorf("Setup should be applied but was %+v", d.stones)
+	}
+
+	if d.labels[Position{3, 3}] != "3" || d.stoneAt(Position{5, 5}) != EMPTY {
+		t.Errorf("Moves after 3 should not be shown but were %+v", d.labels)
+	}
+
+	// Vertices only exist up to 25x25
+	cursor, _ = NewBoardCursor([]byte("(;SZ[30];B[aa];W[ba];B[ca];W[ab];B[bb];W[zz];B[aa])"))
+	for i := 0; i < 7; i++ {
+		cursor.Next(0)
+	}
+
+	d, _ = kifuDiagram(cursor, 2, 7)
+	if len(d.notes) != 1 || d.noteTexts()[0] != "7 at a stone already on the board" {
+		t.Errorf("Note should not contain a vertex but was %+v", d.noteTexts())
+	}
+
+	if err := cursor.RenderSVG(ioutil.Discard, RenderOptions{Coordinates: true}); err == nil {
+		t.Errorf("Coordinates of a 30x30 board should fail!")
+	}
+}
@@ -0,0 +1,151 @@
+package libaduk
+
+import (
+	"fmt"
+)
+
+// Adds a child with the given properties to the current node and moves the cursor to it.
+// If a child with the same move already exists, the cursor moves to that child instead
+func (cursor *Cursor) AddChild(properties ...Property) (*Node, error) {
+	parent := cursor.currentNode
+	node := NewNode(parent)
+	node.properties = append(node.properties, properties...)
+
+	if existing := findChildWithMove(parent, node); existing != nil {
+		return cursor.moveTo(existing)
+	}
+
+	// Append as last sibling
+	if parent.Next == nil {
+		parent.Next = node
+	} else {
+		last := parent.Next
+		for last.Down != nil {
+			last = last.Down
+		}
+		last.Down = node
+		node.Up = last
+	}
+	node.level = parent.numChildren
+	parent.numChildren++
+
+	if _, err := cursor.moveTo(node); err != nil {
+		cursor.removeNode(node)
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// Inserts a node with the given properties between the current node and its parent and moves the cursor to it
+func (cursor *Cursor) InsertBefore(properties ...Property) (*Node, error) {
+	current := cursor.currentNode
+	if current.Previous == nil {
+		return nil, fmt.Errorf("Can't insert a node before the root node!")
+	}
+
+	node := NewNode(current.Previous)
+	node.properties = append(node.properties, properties...)
+
+	// The new node takes the place of the current node in the sibling list
+	replaceNode(current, node)
+	node.Next = current
+	node.numChildren = 1
+	current.Previous, current.Up, current.Down, current.level = node, nil, nil, 0
+
+	if _, err := cursor.moveTo(node); err != nil {
+		replaceNode(node, current)
+		current.Previous = node.Previous
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// Moves the variation one position up in the list of its siblings
+func (cursor *Cursor) MoveVariationUp(node *Node) error {
+	if node.Up == nil {
+		return fmt.Errorf("Variation is already the first one!")
+	}
+
+	cursor.swapSiblings(node.Up, node)
+
+	return nil
+}
+
+// Moves the variation one position down in the list of its siblings
+func (cursor *Cursor) MoveVariationDown(node *Node) error {
+	if node.Down == nil {
+		return fmt.Errorf("Variation is already the last one!")
+	}
+
+	cursor.swapSiblings(node, node.Down)
+
+	return nil
+}
+
+// Makes the node and all its ancestors the first child of their parent, so the node is part of the main line
+func (cursor *Cursor) PromoteToMainLine(node *Node) {
+	for ; node.Previous != nil; node = node.Previous {
+		for node.Up != nil {
+			cursor.swapSiblings(node.Up, node)
+		}
+	}
+}
+
+// Swaps two neighbouring siblings, lower has to be upper.Down
+func (cursor *Cursor) swapSiblings(upper *Node, lower *Node) {
+	if upper.Up != nil {
+		upper.Up.Down = lower
+	} else if upper.Previous != nil {
+		upper.Previous.Next = lower
+	} else if cursor.rootNode == upper {
+		cursor.rootNode = lower
+	}
+
+	if lower.Down != nil {
+		lower.Down.Up = upper
+	}
+
+	lower.Up, upper.Down = upper.Up, lower.Down
+	upper.Up, lower.Down = lower, upper
+	upper.level, lower.level = lower.level, upper.level
+}
+
+// Puts replacement at the place of node in the list of siblings of node
+func replaceNode(node *Node, replacement *Node) {
+	if node.Up != nil {
+		node.Up.Down = replacement
+	} else {
+		node.Previous.Next = replacement
+	}
+
+	if node.Down != nil {
+		node.Down.Up = replacement
+	}
+
+	replacement.Up, replacement.Down, replacement.level = node.Up, node.Down, node.level
+}
+
+// Returns the child of parent that has the same move as node or nil if there is none
+func findChildWithMove(parent *Node, node *Node) *Node {
+	color, value := "B", ""
+	values, ok := node.Property("B")
+	if !ok {
+		color = "W"
+		if values, ok = node.Property("W"); !ok {
+			return nil
+		}
+	}
+	if len(values) > 0 {
+		value = values[0]
+	}
+
+	for child := parent.Next; child != nil; child = child.Down {
+		if childValues, ok := child.Property(color); ok && len(childValues) > 0 && childValues[0] == value {
+			return child
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package libaduk
+
+import (
+	"testing"
+)
+
+// Tree with variations used by the editing tests
+const editTestGame = "(;SZ[9];B[aa](;W[bb];B[cc])(;W[dd](;B[ee])(;B[ff]))(;W[gg]))"
+
+// Checks Up/Down links, levels and the children count of all nodes below node
+func checkTree(t *testing.T, node *Node) {
+	count := 0
+	var up *Node
+
+	for child := node.Next; child != nil; child = child.Down {
+		if child.Up != up || child.Previous != node || child.level != count {
+			t.Errorf("Child %s has wrong links or level %d", child.ToString(), child.level)
+		}
+
+		checkTree(t, child)
+		up = child
+		count++
+	}
+
+	if node.numChildren != count {
+		t.Errorf("Node %s should have %d children but has %d", node.ToString(), count, node.numChildren)
+	}
+}
+
+// Returns the B or W values of the children of node
+func childMoves(node *Node) []string {
+	moves := []string{}
+
+	for child := node.Next; child != nil; child = child.Down {
+		moves = append(moves, child.PropertyValue("B")+child.PropertyValue("W"))
+	}
+
+	return moves
+}
+
+// Tests adding children, existing moves are reused
+func TestCursorAddChild(t *testing.T) {
+	cursor, _ := NewBoardCursor([]byte(editTestGame))
+	root := cursor.Current()
+	cursor.Next(0)
+
+	node, err := cursor.AddChild(Property{"W", []string{"dd"}})
+	if err != nil || node.PropertyValue("W") != "dd" || cursor.Current().Previous.numChildren != 3 {
+		t.Errorf("Existing variation should be reused but was %+v", err)
+	}
+
+	cursor.Previous()
+	node, err = cursor.AddChild(Property{"W", []string{"hh"}}, Property{"C", []string{"New"}})
+	if err != nil || cursor.Current() != node || cursor.Board().getStatus(7, 7) != WHITE {
+		t.Errorf("New variation should be added and played but was %+v", err)
+	}
+
+	if moves := childMoves(node.Previous); len(moves) != 4 || moves[3] != "hh" {
+		t.Errorf("New variation should be the last one but was %+v", moves)
+	}
+
+	cursor.Previous()
+	if _, err := cursor.AddChild(Property{"W", []string{"aa"}}); err == nil || node.Previous.numChildren != 4 {
+		t.Errorf("Illegal move should not be added!")
+	}
+
+	checkTree(t, root)
+}
+
+// Tests inserting a node before the current node
+func TestCursorInsertBefore(t *testing.T) {
+	cursor, _ := NewBoardCursor([]byte(editTestGame))
+	root := cursor.Current()
+	cursor.Next(0)
+	cursor.Next(1)
+	current := cursor.Current()
+
+	node, err := cursor.InsertBefore(Property{"B", []string{"ii"}})
+	if err != nil || node.Next != current || current.Previous != node || cursor.Board().getStatus(8, 8) != BLACK {
+		t.Fatalf("Node should be inserted before W[dd] but was %+v", err)
+	}
+
+	if moves := childMoves(node.Previous); moves[1] != "ii" || len(moves) != 3 {
+		t.Errorf("Inserted node should take the place of W[dd] but was %+v", moves)
+	}
+
+	cursor.Next(0)
+	if cursor.Board().getStatus(3, 3) != WHITE {
+		t.Errorf("Board should show W[dd] after the inserted node!")
+	}
+
+	if _, err := cursor.InsertBefore(Property{"W", []string{"ii"}}); err == nil {
+		t.Errorf("Node with occupied position should not be inserted!")
+	}
+
+	cursor.Previous()
+	cursor.Previous()
+	cursor.Previous()
+	if _, err := cursor.InsertBefore(); err == nil {
+		t.Errorf("Nothing should be inserted before the root!")
+	}
+
+	checkTree(t, root)
+}
+
+// Tests reordering variations
+func TestCursorMoveVariation(t *testing.T) {
+	cursor, _ := NewBoardCursor([]byte(editTestGame))
+	root := cursor.Current()
+	first := root.Next
+
+	if err := cursor.MoveVariationUp(first.Next); err == nil {
+		t.Errorf("First variation should not be moved up!")
+	}
+
+	cursor.MoveVariationDown(first.Next)
+	if moves := childMoves(first); moves[0] != "dd" || moves[1] != "bb" || moves[2] != "gg" {
+		t.Errorf("W[bb] should be moved down but was %+v", moves)
+	}
+
+	cursor.MoveVariationUp(first.Next.Down.Down)
+	if moves := childMoves(first); moves[0] != "dd" || moves[1] != "gg" || moves[2] != "bb" {
+		t.Errorf("W[gg] should be moved up but was %+v", moves)
+	}
+
+	if err := cursor.MoveVariationDown(first.Next.Down.Down); err == nil {
+		t.Errorf("Last variation should not be moved down!")
+	}
+
+	checkTree(t, root)
+}
+
+// Tests promoting a variation to the main line
+func TestCursorPromoteToMainLine(t *testing.T) {
+	cursor, _ := NewBoardCursor([]byte(editTestGame))
+	root := cursor.Current()
+	cursor.Next(0)
+	cursor.Next(1)
+	node, _ := cursor.Next(1)
+
+	cursor.PromoteToMainLine(node)
+
+	mainLine := []string{}
+	for n := root.Next; n != nil; n = n.Next {
+		mainLine = append(mainLine, n.PropertyValue("B")+n.PropertyValue("W"))
+	}
+
+	if len(mainLine) != 3 || mainLine[1] != "dd" || mainLine[2] != "ff" {
+		t.Errorf("Main line should be aa dd ff but was %+v", mainLine)
+	}
+
+	if cursor.Current() != node || cursor.Board().getStatus(5, 5) != BLACK {
+		t.Errorf("Cursor should stay at the promoted node!")
+	}
+
+	checkTree(t, root)
+}
+
+// Tests deleting variations in the middle and all children of the root
+func TestCursorDeleteVariation(t *testing.T) {
+	cursor, _ := NewBoardCursor([]byte(editTestGame))
+	root := cursor.Current()
+	cursor.Next(0)
+	cursor.Next(1)
+	cursor.Next(0)
+
+	// The cursor is inside the deleted variation and moves to its parent
+	cursor.DeleteVariation(root.Next.Next.Down)
+	if moves := childMoves(root.Next); len(moves) != 2 || moves[1] != "gg" || cursor.Current() != root.Next {
+		t.Errorf("W[dd] should be deleted but was %+v", moves)
+	}
+
+	if cursor.Board().getStatus(3, 3) != EMPTY || cursor.Board().getStatus(4, 4) != EMPTY {
+		t.Errorf("Board should not show deleted moves:\n%s", cursor.Board().ToString())
+	}
+	checkTree(t, root)
+
+	cursor.DeleteVariation(root)
+	if root.Next != nil || root.numChildren != 0 || cursor.Current() != root {
+		t.Errorf("All children of the root should be deleted!")
+	}
+}
@@ -0,0 +1,141 @@
+package libaduk
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Decides if a node is a search result
+type NodePredicate func(node *Node) bool
+
+// Matches nodes that have the property
+func HasProperty(identifier string) NodePredicate {
+	return func(node *Node) bool {
+		return node.HasProperty(identifier)
+	}
+}
+
+// Matches nodes with a value of the property that matches re
+func PropertyMatches(identifier string, re *regexp.Regexp) NodePredicate {
+	return func(node *Node) bool {
+		values, _ := node.propertyValues(identifier)
+		for _, value := range values {
+			if re.MatchString(value) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Matches nodes with a comment (C) that matches re
+func CommentMatches(re *regexp.Regexp) NodePredicate {
+	return PropertyMatches("C", re)
+}
+
+// Matches nodes with the given move of black or white, a pass matches "" and "tt" (on boards up to 19x19)
+func HasMove(move Move) NodePredicate {
+	identifier, ok := map[BoardStatus]string{BLACK: "B", WHITE: "W"}[move.Color]
+	if !ok {
+		return func(node *Node) bool { return false }
+	}
+
+	point := positionToPoint(Position{move.X, move.Y})
+
+	return func(node *Node) bool {
+		values, ok := node.propertyValues(identifier)
+		if !ok || len(values) != 1 {
+			return false
+		}
+
+		if values[0] != "tt" {
+			return values[0] == point || (move.IsPass() && values[0] == "")
+		}
+
+		// "tt" is a pass on boards up to 19x19 and a point on larger boards
+		root := node
+		for root.Previous != nil {
+			root = root.Previous
+		}
+
+		boardSize, err := root.BoardSize()
+		if err != nil {
+			return false
+		}
+
+		if isPassValue(values[0], boardSize) {
+			return move.IsPass()
+		}
+
+		return values[0] == point
+	}
+}
+
+// Matches nodes with a move annotation (BM, TE, DO or IT)
+func HasMoveAnnotation() NodePredicate {
+	return AnyOf(HasProperty("BM"), HasProperty("TE"), HasProperty("DO"), HasProperty("IT"))
+}
+
+// Matches nodes that match all predicates
+func AllOf(predicates ...NodePredicate) NodePredicate {
+	return func(node *Node) bool {
+		for _, predicate := range predicates {
+			if !predicate(node) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// Matches nodes that match at least one predicate
+func AnyOf(predicates ...NodePredicate) NodePredicate {
+	return func(node *Node) bool {
+		for _, predicate := range predicates {
+			if predicate(node) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Moves the cursor to the first matching node of the collection in depth-first pre-order
+func (cursor *Cursor) Find(predicate NodePredicate) (*Node, error) {
+	return cursor.findFrom(cursor.rootNode, predicate)
+}
+
+// Moves the cursor to the next matching node after the current node in depth-first pre-order,
+// the search continues with the following games of the collection
+func (cursor *Cursor) FindNext(predicate NodePredicate) (*Node, error) {
+	next, _ := nextPreOrder(nil, cursor.currentNode, 0, true)
+
+	return cursor.findFrom(next, predicate)
+}
+
+// Returns all matching nodes of the collection in depth-first pre-order, the cursor doesn't move
+func (cursor *Cursor) FindAll(predicate NodePredicate) []*Node {
+	nodes := []*Node{}
+
+	for node := cursor.rootNode; node != nil; node, _ = nextPreOrder(nil, node, 0, true) {
+		if predicate(node) {
+			nodes = append(nodes, node)
+		}
+	}
+
+	return nodes
+}
+
+// Moves the cursor to the first matching node starting with node
+func (cursor *Cursor) findFrom(node *Node, predicate NodePredicate) (*Node, error) {
+	for ; node != nil; node, _ = nextPreOrder(nil, node, 0, true) {
+		if predicate(node) {
+			return cursor.moveTo(node)
+		}
+	}
+
+	return nil, fmt.Errorf("No matching node found!")
+}
@@ -0,0 +1,107 @@
+package libaduk
+
+import (
+	"io/ioutil"
+	"regexp"
+	"testing"
+)
+
+// Tree with comments and annotations used by the search tests
+const findTestGame = "(;SZ[9]C[Start];B[aa]C[A mistake]BM[1](;W[bb]TE[1];B[cc]LB[dd:a])(;W[dd]C[Another mistake];B[]))(;SZ[9];B[ee]C[mistake])"
+
+// Tests the predicates and FindAll
+func TestCursorFindAll(t *testing.T) {
+	cursor, _ := NewCursor([]byte(findTestGame))
+	mistake := regexp.MustCompile(`(?i)mistake`)
+
+	tests := []struct {
+		name      string
+		predicate NodePredicate
+		expected  int
+	}{
+		{"Comments", CommentMatches(mistake), 3},
+		{"Labels", HasProperty("LB"), 1},
+		{"Move", HasMove(Move{X: 3, Y: 3, Color: WHITE}), 1},
+		{"Pass", HasMove(Move{X: 255, Y: 255, Color: BLACK}), 1},
+		{"Annotations", HasMoveAnnotation(), 2},
+		{"All", AllOf(CommentMatches(mistake), HasProperty("BM")), 1},
+		{"Any", AnyOf(HasProperty("TE"), PropertyMatches("LB", regexp.MustCompile(`:a$`))), 2},
+	}
+
+	for _, test := range tests {
+		if nodes := cursor.FindAll(test.predicate); len(nodes) != test.expected {
+			t.Errorf("%s should find %d nodes but found %d", test.name, test.expected, len(nodes))
+		}
+	}
+
+	if cursor.Current() != cursor.rootNode {
+		t.Errorf("FindAll should not move the cursor!")
+	}
+}
+
+// Tests that "tt" is only a pass on boards up to 19x19 and that only black and white moves match
+func TestHasMovePassAndColor(t *testing.T) {
+	pass := HasMove(Move{X: 255, Y: 255, Color: BLACK})
+	cursor, _ := NewCursor([]byte("(;SZ[19];B[tt])(;SZ[21];B[tt])"))
+
+	if nodes := cursor.FindAll(pass); len(nodes) != 1 || nodes[0].Previous.PropertyValue("SZ") != "19" {
+		t.Errorf("Only B[tt] on 19x19 should be a pass but found %d nodes", len(nodes))
+	}
+
+	if nodes := cursor.FindAll(HasMove(Move{X: 19, Y: 19, Color: BLACK})); len(nodes) != 1 || nodes[0].Previous.PropertyValue("SZ") != "21" {
+		t.Errorf("B[tt] on 21x21 should be a move but found %d nodes", len(nodes))
+	}
+
+	if nodes := cursor.FindAll(HasMove(Move{X: 19, Y: 19, Color: EMPTY})); len(nodes) != 0 {
+		t.Errorf("Moves without color should not match but found %d nodes", len(nodes))
+	}
+}
+
+// Tests moving from match to match over all games of the collection
+func TestCursorFindNext(t *testing.T) {
+	cursor, _ := NewBoardCursor([]byte(findTestGame))
+	predicate := CommentMatches(regexp.MustCompile(`mistake`))
+
+	node, err := cursor.Find(predicate)
+	if err != nil || node.PropertyValue("C") != "A mistake" || cursor.Board().getStatus(0, 0) != BLACK {
+		t.Fatalf("First mistake should be found but was %+v", err)
+	}
+
+	node, _ = cursor.FindNext(predicate)
+	if node.PropertyValue("W") != "dd" || cursor.Board().getStatus(3, 3) != WHITE {
+		t.Errorf("Next mistake should be W[dd] but was %s", node.ToString())
+	}
+
+	node, _ = cursor.FindNext(predicate)
+	if node.PropertyValue("B") != "ee" || cursor.Board().getStatus(4, 4) != BLACK || cursor.Board().getStatus(0, 0) != EMPTY {
+		t.Errorf("Next mistake should be in the second game but was %s", node.ToString())
+	}
+
+	if _, err := cursor.FindNext(predicate); err == nil || cursor.Current() != node {
+		t.Errorf("There should be no more mistakes!")
+	}
+}
+
+// Tests searching the comments of the joseki dictionary
+func TestCursorFindJosekiDictionary(t *testing.T) {
+	sgfData, _ := ioutil.ReadFile(TestgameKogo)
+	cursor, _ := NewCursor(sgfData)
+	predicate := CommentMatches(regexp.MustCompile(`(?i)trick play`))
+
+	all := cursor.FindAll(predicate)
+	if len(all) == 0 {
+		t.Fatalf("Dictionary should contain trick plays!")
+	}
+
+	found := 0
+	for node, err := cursor.Find(predicate); err == nil; node, err = cursor.FindNext(predicate) {
+		if node != all[found] {
+			t.Fatalf("Match %d should be the same as FindAll returned", found)
+		}
+		found++
+	}
+
+	if found != len(all) {
+		t.Errorf("FindNext should find %d nodes but found %d", len(all), found)
+	}
+}
@@ -0,0 +1,289 @@
+package libaduk
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+var (
+	ErrGameOver    = errors.New("Game is already over!")
+	ErrNotYourTurn = errors.New("Invalid move (Not your turn)!")
+)
+
+// Reason why a game ended
+type EndReason uint8
+
+const (
+	NOT_ENDED   EndReason = iota // The game is still being played
+	END_PASSES                   // Both players passed
+	END_RESIGN                   // A player resigned
+	END_TIMEOUT                  // A player ran out of time
+)
+
+// A game played on a board that enforces the turn order and records the moves as SGF tree
+type Game struct {
+	Komi       float64
+	board      *AbstractBoard
+	root       *Node
+	current    *Node
+	moveNumber int
+	passes     int                 // Number of consecutive passes
+	passStones map[BoardStatus]int // Prisoners every color got for the passes of the opponent
+	reason     EndReason
+	winner     BoardStatus
+}
+
+// Create a new game with the given rules, handicap stones and komi
+func NewGame(boardSize uint8, rules Ruleset, handicap int, komi float64) (*Game, error) {
+	board, err := NewBoardWithRules(boardSize, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	root := NewNode(nil)
+	root.SetProperty("GM", "1")
+	root.SetProperty("FF", "4")
+	root.SetProperty("SZ", strconv.Itoa(int(boardSize)))
+	root.SetProperty("RU", rules.Name)
+	root.SetProperty("KM", strconv.FormatFloat(komi, 'f', -1, 64))
+
+	if handicap > 0 {
+		positions, err := board.SetFixedHandicap(handicap)
+		if err != nil {
+			return nil, err
+		}
+
+		root.SetProperty("HA", strconv.Itoa(handicap))
+		for _, position := range positions {
+			root.AddPropertyValue("AB", positionToPoint(position))
+		}
+	}
+
+	game := &Game{
+		Komi:       komi,
+		board:      board,
+		root:       root,
+		current:    root,
+		passStones: map[BoardStatus]int{BLACK: 0, WHITE: 0},
+	}
+
+	return game, nil
+}
+
+// Returns the board of the game
+func (game *Game) Board() *AbstractBoard {
+	return game.board
+}
+
+// Returns the root node of the SGF tree of the game
+func (game *Game) Root() *Node {
+	return game.root
+}
+
+// Writes the game as SGF
+func (game *Game) WriteSGF(w io.Writer) error {
+	return writeCollection(w, game.root)
+}
+
+// Returns the color that has to move next
+func (game *Game) NextColor() BoardStatus {
+	return game.board.NextColor()
+}
+
+// Returns the number of moves (including passes) played so far
+func (game *Game) MoveNumber() int {
+	return game.moveNumber
+}
+
+// Returns the number of consecutive passes at the end of the game
+func (game *Game) Passes() int {
+	return game.passes
+}
+
+// Returns the number of prisoners color got for passes of the opponent
+func (game *Game) PassStones(color BoardStatus) int {
+	return game.passStones[color]
+}
+
+// Checks if the game has ended
+func (game *Game) IsOver() bool {
+	return game.reason != NOT_ENDED
+}
+
+// Returns why the game ended
+func (game *Game) EndReason() EndReason {
+	return game.reason
+}
+
+// Returns the winner of a game that ended by resignation or timeout, EMPTY otherwise
+func (game *Game) Winner() BoardStatus {
+	return game.winner
+}
+
+// Play a stone for the color that has to move
+func (game *Game) Play(x uint8, y uint8) error {
+	return game.PlayMove(Move{X: x, Y: y, Color: game.NextColor()})
+}
+
+// Play the move, it has to be a move or pass of the color that has to move.
+// A pass with the color PASS is a pass of the color that has to move
+func (game *Game) PlayMove(move Move) error {
+	if game.IsOver() {
+		return ErrGameOver
+	}
+
+	if move.Color != PASS && move.Color != game.NextColor() {
+		return ErrNotYourTurn
+	}
+
+	if move.IsPass() {
+		return game.Pass()
+	}
+
+	if err := game.board.Play(move.X, move.Y, move.Color); err != nil {
+		return err
+	}
+
+	game.passes = 0
+	game.addMoveNode(move.Color, positionToPoint(Position{move.X, move.Y}))
+
+	return nil
+}
+
+// Pass for the color that has to move. The game ends after two consecutive passes,
+// if the rules require it white has to pass last
+func (game *Game) Pass() error {
+	if game.IsOver() {
+		return ErrGameOver
+	}
+
+	color := game.NextColor()
+	game.board.UndostackPushPass()
+	game.passes++
+
+	if game.board.rules.PassStones {
+		game.passStones[color.invert()]++
+	}
+
+	game.addMoveNode(color, "")
+
+	if game.passes >= 2 && (color == WHITE || !game.board.rules.WhitePassesLast) {
+		game.reason = END_PASSES
+	}
+
+	return nil
+}
+
+// The given color resigns
+func (game *Game) Resign(color BoardStatus) error {
+	return game.end(END_RESIGN, color, "R")
+}
+
+// The given color ran out of time
+func (game *Game) Timeout(color BoardStatus) error {
+	return game.end(END_TIMEOUT, color, "T")
+}
+
+// Take back the last move or pass, a game that ended by passing continues
+func (game *Game) Undo() error {
+	if game.reason == END_RESIGN || game.reason == END_TIMEOUT {
+		return ErrGameOver
+	}
+
+	if game.current == game.root {
+		return fmt.Errorf("No move to undo!")
+	}
+
+	game.board.Undo(1)
+	game.current = game.current.Previous
+	game.current.Next = nil
+	game.current.numChildren = 0
+	game.moveNumber--
+	game.countPasses()
+
+	return nil
+}
+
+// Counts the passes of the moves up to the current node and decides if they ended the game
+func (game *Game) countPasses() {
+	game.passes = 0
+	game.passStones = map[BoardStatus]int{BLACK: 0, WHITE: 0}
+	game.reason = NOT_ENDED
+
+	lastPass := EMPTY
+	consecutive := true
+	for node := game.current; node != game.root; node = node.Previous {
+		move, _ := node.Move(game.board.BoardSize)
+		if move == nil || !move.IsPass() {
+			consecutive = false
+			continue
+		}
+
+		if consecutive {
+			if game.passes == 0 {
+				lastPass = move.Color
+			}
+			game.passes++
+		}
+
+		if game.board.rules.PassStones {
+			game.passStones[move.Color.invert()]++
+		}
+	}
+
+	if game.passes >= 2 && (lastPass == WHITE || !game.board.rules.WhitePassesLast) {
+		game.reason = END_PASSES
+	}
+}
+
+// Counts the score of a game that ended by passing and records the result in the SGF tree
+func (game *Game) Score(deadStones []Position) (*Result, error) {
+	if game.reason != END_PASSES {
+		return nil, fmt.Errorf("Game has not ended by passing!")
+	}
+
+	result, err := game.board.Score(deadStones, game.Komi)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pass stones are prisoners, they only count with territory scoring
+	if result.Method == TERRITORY_SCORING {
+		result.Black += float64(game.passStones[BLACK])
+		result.White += float64(game.passStones[WHITE])
+	}
+
+	game.root.SetProperty("RE", result.String())
+
+	return result, nil
+}
+
+// Ends the game with a loss of color
+func (game *Game) end(reason EndReason, color BoardStatus, suffix string) error {
+	if game.IsOver() {
+		return ErrGameOver
+	}
+
+	if color != BLACK && color != WHITE {
+		return fmt.Errorf("Invalid color (%d)!", color)
+	}
+
+	game.reason = reason
+	game.winner = color.invert()
+	game.root.SetProperty("RE", GTPColorString(game.winner)+"+"+suffix)
+
+	return nil
+}
+
+// Appends a node with the move to the SGF tree
+func (game *Game) addMoveNode(color BoardStatus, point string) {
+	node := NewNode(game.current)
+	node.SetProperty(GTPColorString(color), point)
+
+	game.current.Next = node
+	game.current.numChildren = 1
+	game.current = node
+	game.moveNumber++
+}
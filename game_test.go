@@ -0,0 +1,158 @@
+package libaduk
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// Tests turn order, passes and the SGF tree of a short game
+func TestGamePassesAndSGF(t *testing.T) {
+	game, _ := NewGame(9, JapaneseRules, 0, 6.5)
+
+	if err := game.Play(2, 2); err != nil {
+		t.Fatalf("Black should be able to play but was %+v", err)
+	}
+
+	if err := game.PlayMove(Move{X: 3, Y: 3, Color: BLACK}); err != ErrNotYourTurn {
+		t.Errorf("Black should not be able to move twice but was %+v", err)
+	}
+
+	game.Play(6, 6)
+	game.Pass()
+	if game.IsOver() || game.NextColor() != WHITE {
+		t.Errorf("Game should continue with white after a single pass!")
+	}
+
+	game.Pass()
+	if !game.IsOver() || game.EndReason() != END_PASSES || game.MoveNumber() != 4 {
+		t.Errorf("Game should end after two passes but was %+v", game)
+	}
+
+	if err := game.Play(4, 4); err != ErrGameOver {
+		t.Errorf("Moves after the end should fail but was %+v", err)
+	}
+
+	result, err := game.Score(nil)
+	if err != nil || result.String() != "W+6.50" {
+		t.Errorf("Result should be W+6.50 but was %s (%+v)", result, err)
+	}
+
+	var buffer bytes.Buffer
+	game.WriteSGF(&buffer)
+
+	expected := "(;GM[1]FF[4]SZ[9]RU[Japanese]KM[6.5]RE[W+6.50]\n;B[cc]\n;W[gg]\n;B[]\n;W[]\n)"
+	if strings.TrimSpace(buffer.String()) != expected {
+		t.Errorf("SGF should be %s but was %s", expected, buffer.String())
+	}
+
+	// The recorded game can be replayed
+	cursor, err := NewBoardCursor(buffer.Bytes())
+	if err != nil {
+		t.Fatalf("SGF should be replayed but was %+v", err)
+	}
+	cursor.Next(0)
+	cursor.Next(0)
+	if cursor.Board().GetHash() != game.Board().GetHash() {
+		t.Errorf("Replayed board should be the same as the game board!")
+	}
+}
+
+// Tests passing with PlayMove, a pass with the color PASS is a pass of the color that has to move
+func TestGamePlayMovePass(t *testing.T) {
+	game, _ := NewGame(9, JapaneseRules, 0, 6.5)
+	game.Play(2, 2)
+
+	if err := game.PlayMove(Move{X: 255, Y: 255, Color: BLACK}); err != ErrNotYourTurn {
+		t.Errorf("Black should not be able to pass for white but was %+v", err)
+	}
+
+	if err := game.PlayMove(Move{X: 255, Y: 255, Color: PASS}); err != nil || game.Passes() != 1 || game.NextColor() != BLACK {
+		t.Errorf("White should pass but was %+v (%+v)", game, err)
+	}
+
+	if err := game.PlayMove(Move{X: 255, Y: 255, Color: BLACK}); err != nil || !game.IsOver() {
+		t.Errorf("Game should end after the pass of black but was %+v (%+v)", game, err)
+	}
+}
+
+// Tests that white has to pass last under AGA rules and that passes hand over prisoners
+func TestGameAGAPasses(t *testing.T) {
+	game, _ := NewGame(9, AGARules, 0, 7.5)
+
+	game.Play(2, 2)
+	game.Pass()
+	game.Pass()
+
+	if game.IsOver() || game.PassStones(BLACK) != 1 || game.PassStones(WHITE) != 1 {
+		t.Errorf("Game should not end when black passed last but was %+v", game)
+	}
+
+	game.Pass()
+	if !game.IsOver() {
+		t.Errorf("Game should end when white passes last!")
+	}
+
+	game.Undo()
+	if game.IsOver() || game.Passes() != 2 || game.PassStones(BLACK) != 1 || game.NextColor() != WHITE {
+		t.Errorf("Undo should continue the game but was %+v", game)
+	}
+}
+
+// Tests that undoing a move restores the passes before it
+func TestGameUndoRestoresPasses(t *testing.T) {
+	game, _ := NewGame(9, JapaneseRules, 0, 6.5)
+
+	game.Pass()
+	game.Play(2, 2)
+	game.Undo()
+
+	if game.Passes() != 1 || game.NextColor() != WHITE {
+		t.Errorf("Undo should restore the pass of black but was %d passes", game.Passes())
+	}
+
+	game.Pass()
+	if !game.IsOver() || game.EndReason() != END_PASSES {
+		t.Errorf("Pass of white should end the game but was %+v", game)
+	}
+
+	game, _ = NewGame(9, AGARules, 0, 7.5)
+	game.Pass()
+	game.Play(2, 2)
+	game.Undo()
+
+	if game.Passes() != 1 || game.PassStones(WHITE) != 1 || game.PassStones(BLACK) != 0 {
+		t.Errorf("Undo should keep the pass stone of white but was %+v", game)
+	}
+}
+
+// Tests resignation, timeout and handicap games
+func TestGameResignAndHandicap(t *testing.T) {
+	game, err := NewGame(19, JapaneseRules, 4, 0.5)
+	if err != nil {
+		t.Fatalf("Handicap game should be created but was %+v", err)
+	}
+
+	if game.NextColor() != WHITE || game.Root().PropertyValue("HA") != "4" {
+		t.Errorf("White should move first in a handicap game!")
+	}
+
+	game.Play(9, 9)
+	if err := game.Resign(WHITE); err != nil || game.Winner() != BLACK || game.Root().PropertyValue("RE") != "B+R" {
+		t.Errorf("Black should win by resignation but was %+v", game.Root().PropertyValue("RE"))
+	}
+
+	if err := game.Timeout(BLACK); err != ErrGameOver {
+		t.Errorf("Ended game should not end again but was %+v", err)
+	}
+
+	if _, err := game.Score(nil); err == nil {
+		t.Errorf("Resigned game should not be scored!")
+	}
+
+	game, _ = NewGame(9, JapaneseRules, 0, 0.5)
+	game.Timeout(BLACK)
+	if game.EndReason() != END_TIMEOUT || game.Root().PropertyValue("RE") != "W+T" {
+		t.Errorf("White should win on time but was %+v", game.Root().PropertyValue("RE"))
+	}
+}
@@ -0,0 +1,418 @@
+package libaduk
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Returned by a MoveGenerator that wants to resign
+var ErrResign = errors.New("Resign")
+
+// Generates moves for the GTP genmove command
+type MoveGenerator interface {
+	// Returns the move color should play on board. A pass is a move with X and Y 255
+	GenerateMove(board *AbstractBoard, color BoardStatus) (Move, error)
+}
+
+// GTP (Go Text Protocol version 2) engine front-end that plays on an AbstractBoard
+type GTPServer struct {
+	Name      string
+	Version   string
+	generator MoveGenerator
+	board     *AbstractBoard
+	komi      float64
+	commands  map[string]func(args []string) (string, error)
+}
+
+// Create a new GTP server, genmove uses the given generator
+func NewGTPServer(name string, version string, generator MoveGenerator) *GTPServer {
+	board, _ := NewBoard(19)
+	server := &GTPServer{Name: name, Version: version, generator: generator, board: board}
+
+	server.commands = map[string]func(args []string) (string, error){
+		"protocol_version":    func(args []string) (string, error) { return "2", nil },
+		"name":                func(args []string) (string, error) { return server.Name, nil },
+		"version":             func(args []string) (string, error) { return server.Version, nil },
+		"known_command":       server.knownCommand,
+		"list_commands":       server.listCommands,
+		"quit":                func(args []string) (string, error) { return "", nil },
+		"boardsize":           server.boardSize,
+		"clear_board":         server.clearBoard,
+		"komi":                server.setKomi,
+		"fixed_handicap":      server.fixedHandicap,
+		"place_free_handicap": server.placeFreeHandicap,
+		"set_free_handicap":   server.setFreeHandicap,
+		"play":                server.play,
+		"genmove":             server.genMove,
+		"undo":                server.undo,
+		"showboard": func(args []string) (string, error) {
+			return "\n" + strings.TrimRight(server.board.ToString(), "\n"), nil
+		},
+		"final_score": server.finalScore,
+	}
+
+	return server
+}
+
+// Returns the board of the server
+func (server *GTPServer) Board() *AbstractBoard {
+	return server.board
+}
+
+// Reads commands from r and writes the responses to w until quit is received or r ends
+func (server *GTPServer) Serve(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		id, name, args := parseGTPCommand(scanner.Text())
+		if name == "" {
+			continue
+		}
+
+		var result string
+		var err error
+
+		if command, ok := server.commands[name]; ok {
+			result, err = command(args)
+		} else {
+			err = fmt.Errorf("unknown command")
+		}
+
+		if err != nil {
+			_, err = fmt.Fprintf(w, "?%s %s\n\n", id, err)
+		} else {
+			_, err = fmt.Fprintf(w, "=%s %s\n\n", id, result)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if name == "quit" {
+			return nil
+		}
+	}
+
+	return scanner.Err()
+}
+
+// Splits a command line into id, command name and arguments. Comments and control characters are removed
+func parseGTPCommand(line string) (id string, name string, args []string) {
+	if index := strings.IndexByte(line, '#'); index != -1 {
+		line = line[:index]
+	}
+
+	line = strings.Map(func(r rune) rune {
+		if r == '\t' {
+			return ' '
+		}
+		if r < 32 || r == 127 {
+			return -1
+		}
+		return r
+	}, line)
+
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", "", nil
+	}
+
+	if _, err := strconv.Atoi(fields[0]); err == nil {
+		id = fields[0]
+		fields = fields[1:]
+	}
+
+	if len(fields) == 0 {
+		return id, "", nil
+	}
+
+	return id, fields[0], fields[1:]
+}
+
+func (server *GTPServer) knownCommand(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("syntax error")
+	}
+
+	_, ok := server.commands[args[0]]
+
+	return strconv.FormatBool(ok), nil
+}
+
+func (server *GTPServer) listCommands(args []string) (string, error) {
+	names := []string{}
+
+	for _, name := range gtpCommandOrder {
+		if _, ok := server.commands[name]; ok {
+			names = append(names, name)
+		}
+	}
+
+	return strings.Join(names, "\n"), nil
+}
+
+// Order in which list_commands returns the commands
+var gtpCommandOrder = []string{
+	"protocol_version", "name", "version", "known_command", "list_commands", "quit",
+	"boardsize", "clear_board", "komi", "fixed_handicap", "place_free_handicap", "set_free_handicap",
+	"play", "genmove", "undo", "showboard", "final_score",
+}
+
+func (server *GTPServer) boardSize(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("syntax error")
+	}
+
+	size, err := strconv.Atoi(args[0])
+	if err != nil {
+		return "", fmt.Errorf("syntax error")
+	}
+
+	if size < 2 || size > 25 {
+		return "", fmt.Errorf("unacceptable size")
+	}
+
+	board, err := NewBoardWithRules(uint8(size), server.board.Rules())
+	if err != nil {
+		return "", fmt.Errorf("unacceptable size")
+	}
+
+	server.board = board
+
+	return "", nil
+}
+
+func (server *GTPServer) clearBoard(args []string) (string, error) {
+	server.board.Clear()
+
+	return "", nil
+}
+
+func (server *GTPServer) setKomi(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("syntax error")
+	}
+
+	komi, err := strconv.ParseFloat(args[0], 64)
+	if err != nil {
+		return "", fmt.Errorf("syntax error")
+	}
+
+	server.komi = komi
+
+	return "", nil
+}
+
+func (server *GTPServer) play(args []string) (string, error) {
+	if len(args) != 2 {
+		return "", fmt.Errorf("syntax error")
+	}
+
+	color, err := ParseGTPColor(args[0])
+	if err != nil {
+		return "", fmt.Errorf("syntax error")
+	}
+
+	move, err := ParseVertex(args[1], server.board.BoardSize)
+	if err != nil {
+		return "", fmt.Errorf("syntax error")
+	}
+
+	move.Color = color
+	if err := server.board.PlayMove(move); err != nil {
+		return "", fmt.Errorf("illegal move")
+	}
+
+	return "", nil
+}
+
+func (server *GTPServer) genMove(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("syntax error")
+	}
+
+	color, err := ParseGTPColor(args[0])
+	if err != nil {
+		return "", fmt.Errorf("syntax error")
+	}
+
+	if server.generator == nil {
+		return "", fmt.Errorf("no move generator")
+	}
+
+	move, err := server.generator.GenerateMove(server.board, color)
+	if err == ErrResign {
+		return "resign", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	move.Color = color
+	if err := server.board.PlayMove(move); err != nil {
+		return "", fmt.Errorf("generated illegal move")
+	}
+
+	return VertexString(move, server.board.BoardSize), nil
+}
+
+func (server *GTPServer) undo(args []string) (string, error) {
+	// Handicap stones can't be taken back
+	if len(server.board.undoStack) <= server.board.Handicap() {
+		return "", fmt.Errorf("cannot undo")
+	}
+
+	server.board.Undo(1)
+
+	return "", nil
+}
+
+func (server *GTPServer) finalScore(args []string) (string, error) {
+	result, err := server.board.Score(nil, server.komi)
+	if err != nil {
+		return "", fmt.Errorf("cannot score")
+	}
+
+	return result.String(), nil
+}
+
+func (server *GTPServer) fixedHandicap(args []string) (string, error) {
+	return server.placeHandicap(args, FixedHandicapPositions)
+}
+
+// The engine chooses the fixed handicap positions as far as possible and places further stones near the center
+func (server *GTPServer) placeFreeHandicap(args []string) (string, error) {
+	return server.placeHandicap(args, FreeHandicapPositions)
+}
+
+// Places the handicap stones at the positions returned by positionsFor and returns their vertices
+func (server *GTPServer) placeHandicap(args []string, positionsFor func(uint8, int) ([]Position, error)) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("syntax error")
+	}
+
+	stones, err := strconv.Atoi(args[0])
+	if err != nil {
+		return "", fmt.Errorf("syntax error")
+	}
+
+	if !server.board.isEmpty() {
+		return "", fmt.Errorf("board not empty")
+	}
+
+	positions, err := positionsFor(server.board.BoardSize, stones)
+	if err != nil {
+		return "", fmt.Errorf("invalid number of stones")
+	}
+
+	if err := server.board.SetFreeHandicap(positions); err != nil {
+		return "", fmt.Errorf("invalid number of stones")
+	}
+
+	return server.vertexList(positions), nil
+}
+
+func (server *GTPServer) setFreeHandicap(args []string) (string, error) {
+	if !server.board.isEmpty() {
+		return "", fmt.Errorf("board not empty")
+	}
+
+	positions := []Position{}
+	for _, vertex := range args {
+		move, err := ParseVertex(vertex, server.board.BoardSize)
+		if err != nil || move.IsPass() {
+			return "", fmt.Errorf("bad vertex list")
+		}
+
+		positions = append(positions, Position{move.X, move.Y})
+	}
+
+	if err := server.board.SetFreeHandicap(positions); err != nil {
+		return "", fmt.Errorf("bad vertex list")
+	}
+
+	return "", nil
+}
+
+// Returns the vertices of the positions separated by spaces
+func (server *GTPServer) vertexList(positions []Position) string {
+	vertices := []string{}
+
+	for _, position := range positions {
+		vertices = append(vertices, VertexString(Move{X: position.X, Y: position.Y}, server.board.BoardSize))
+	}
+
+	return strings.Join(vertices, " ")
+}
+
+// Parses a GTP color ("b", "black", "w" or "white")
+func ParseGTPColor(color string) (BoardStatus, error) {
+	switch strings.ToLower(color) {
+	case "b", "black":
+		return BLACK, nil
+	case "w", "white":
+		return WHITE, nil
+	}
+
+	return EMPTY, fmt.Errorf("Invalid color %q!", color)
+}
+
+// Returns the GTP representation of a color
+func GTPColorString(color BoardStatus) string {
+	if color == WHITE {
+		return "W"
+	}
+
+	return "B"
+}
+
+// Parses a GTP vertex (e.g. "D4" or "pass") to a move without color. Column I is skipped and row 1 is the bottom row
+func ParseVertex(vertex string, boardSize uint8) (Move, error) {
+	vertex = strings.ToUpper(vertex)
+
+	if vertex == "PASS" {
+		return Move{X: 255, Y: 255}, nil
+	}
+
+	if len(vertex) < 2 || vertex[0] < 'A' || vertex[0] > 'Z' || vertex[0] == 'I' {
+		return Move{}, fmt.Errorf("Invalid vertex %q!", vertex)
+	}
+
+	x := vertex[0] - 'A'
+	if vertex[0] > 'I' {
+		x--
+	}
+
+	row, err := strconv.Atoi(vertex[1:])
+	if err != nil || row < 1 || row > int(boardSize) || x >= boardSize {
+		return Move{}, fmt.Errorf("Invalid vertex %q!", vertex)
+	}
+
+	return Move{X: x, Y: boardSize - uint8(row)}, nil
+}
+
+// Returns the GTP vertex of the given move (e.g. "D4" or "pass")
+func VertexString(move Move, boardSize uint8) string {
+	if move.IsPass() {
+		return "pass"
+	}
+
+	return fmt.Sprintf("%c%d", columnLetter(move.X), int(boardSize)-int(move.Y))
+}
+
+// Largest board with vertices, the letters A-Z without I are the columns
+const maxVertexBoardSize = 25
+
+// Returns the letter of the column, only valid for boards up to maxVertexBoardSize
+func columnLetter(x uint8) byte {
+	column := 'A' + x
+	if column >= 'I' {
+		column++
+	}
+
+	return column
+}
@@ -0,0 +1,89 @@
+package libaduk
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// Always plays the first empty position
+type firstEmptyGenerator struct{}
+
+func (generator firstEmptyGenerator) GenerateMove(board *AbstractBoard, color BoardStatus) (Move, error) {
+	for x := uint8(0); x < board.BoardSize; x++ {
+		for y := uint8(0); y < board.BoardSize; y++ {
+			if board.getStatus(x, y) == EMPTY {
+				return Move{X: x, Y: y, Color: color}, nil
+			}
+		}
+	}
+
+	return Move{X: 255, Y: 255, Color: color}, nil
+}
+
+// Sends the commands to a new server and returns the output
+func runGTP(commands ...string) string {
+	var output bytes.Buffer
+	server := NewGTPServer("test", "1.0", firstEmptyGenerator{})
+	server.Serve(strings.NewReader(strings.Join(commands, "\n")), &output)
+
+	return output.String()
+}
+
+// Tests a small GTP session
+func TestGTPServerSession(t *testing.T) {
+	output := runGTP(
+		"1 protocol_version",
+		"# a comment",
+		"2 boardsize 5",
+		"3 play black C3",
+		"4 play w c3",
+		"5 genmove w",
+		"6 komi 0.5",
+		"7 final_score",
+		"8 undo",
+		"9 unknown_command",
+		"10 quit",
+		"11 name",
+	)
+
+	expected := "=1 2\n\n=2 \n\n=3 \n\n?4 illegal move\n\n=5 A5\n\n=6 \n\n=7 W+0.50\n\n=8 \n\n?9 unknown command\n\n=10 \n\n"
+	if output != expected {
+		t.Errorf("GTP output should be %q but was %q", expected, output)
+	}
+}
+
+// Tests showboard and list_commands
+func TestGTPServerShowboardAndListCommands(t *testing.T) {
+	output := runGTP("boardsize 3", "play b B2", "showboard", "known_command genmove", "list_commands")
+
+	if !strings.Contains(output, "= \n. . . \n. X . \n. . . \n\n") {
+		t.Errorf("Showboard should show the board but was %q", output)
+	}
+
+	if !strings.Contains(output, "= true\n\n") || !strings.Contains(output, "\nfinal_score\n\n") {
+		t.Errorf("genmove and final_score should be known commands but was %q", output)
+	}
+}
+
+// Tests conversion between GTP vertices and moves
+func TestGTPVertex(t *testing.T) {
+	vertices := map[string]Move{"A19": {X: 0, Y: 0}, "J1": {X: 8, Y: 18}, "T10": {X: 18, Y: 9}, "pass": {X: 255, Y: 255}}
+
+	for vertex, move := range vertices {
+		parsed, err := ParseVertex(vertex, 19)
+		if err != nil || parsed.X != move.X || parsed.Y != move.Y {
+			t.Errorf("Vertex %s should be %+v but was %+v (%+v)", vertex, move, parsed, err)
+		}
+
+		if VertexString(move, 19) != vertex {
+			t.Errorf("Move %+v should be vertex %s but was %s", move, vertex, VertexString(move, 19))
+		}
+	}
+
+	for _, vertex := range []string{"I5", "A20", "Z1", "A0", "A"} {
+		if _, err := ParseVertex(vertex, 19); err == nil {
+			t.Errorf("Vertex %s should be invalid!", vertex)
+		}
+	}
+}
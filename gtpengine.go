@@ -0,0 +1,330 @@
+package libaduk
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// GTP controller that drives an external engine and keeps a local board in sync
+type GTPEngine struct {
+	reader *bufio.Reader
+	writer io.Writer
+	closer io.Closer
+	cmd    *exec.Cmd
+	board  *AbstractBoard
+	nextID int
+}
+
+// Combines the pipes of an engine process
+type processPipes struct {
+	io.Reader
+	io.WriteCloser
+}
+
+// Create a new controller for an engine that reads commands from and writes responses to rw
+func NewGTPEngine(rw io.ReadWriter) *GTPEngine {
+	board, _ := NewBoard(19)
+	engine := &GTPEngine{reader: bufio.NewReader(rw), writer: rw, board: board, nextID: 1}
+
+	if closer, ok := rw.(io.Closer); ok {
+		engine.closer = closer
+	}
+
+	return engine
+}
+
+// Start the engine executable with the given arguments and create a controller for it
+func StartGTPEngine(name string, args ...string) (*GTPEngine, error) {
+	cmd := exec.Command(name, args...)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	engine := NewGTPEngine(processPipes{stdout, stdin})
+	engine.cmd = cmd
+
+	return engine, nil
+}
+
+// Returns the local board that is kept in sync with the engine
+func (engine *GTPEngine) Board() *AbstractBoard {
+	return engine.board
+}
+
+// Sends a command to the engine and returns the response. Failure responses are returned as error
+func (engine *GTPEngine) Command(name string, args ...string) (string, error) {
+	id := engine.nextID
+	engine.nextID++
+
+	line := strings.TrimSpace(strconv.Itoa(id) + " " + name + " " + strings.Join(args, " "))
+	if _, err := io.WriteString(engine.writer, line+"\n"); err != nil {
+		return "", err
+	}
+
+	return engine.readResponse(id)
+}
+
+// Reads a response up to the terminating empty line
+func (engine *GTPEngine) readResponse(id int) (string, error) {
+	lines := []string{}
+
+	for {
+		line, err := engine.reader.ReadString('\n')
+		line = strings.TrimRight(line, "\r\n")
+
+		if err != nil {
+			if err == io.EOF && len(lines) > 0 {
+				break
+			}
+			return "", err
+		}
+
+		// Skip empty lines before the response
+		if line == "" {
+			if len(lines) > 0 {
+				break
+			}
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	first := lines[0]
+	if first[0] != '=' && first[0] != '?' {
+		return "", fmt.Errorf("Invalid GTP response %q!", first)
+	}
+
+	// Remove status character and id
+	text := first[1:]
+	if responseID := strconv.Itoa(id); strings.HasPrefix(text, responseID) {
+		text = text[len(responseID):]
+	}
+	lines[0] = strings.TrimPrefix(text, " ")
+	response := strings.Join(lines, "\n")
+
+	if first[0] == '?' {
+		return "", fmt.Errorf("GTP error: %s", response)
+	}
+
+	return response, nil
+}
+
+// Returns the name of the engine
+func (engine *GTPEngine) Name() (string, error) {
+	return engine.Command("name")
+}
+
+// Returns the version of the engine
+func (engine *GTPEngine) Version() (string, error) {
+	return engine.Command("version")
+}
+
+// Set the board size and clear the board
+func (engine *GTPEngine) BoardSize(size uint8) error {
+	board, err := NewBoardWithRules(size, engine.board.Rules())
+	if err != nil {
+		return err
+	}
+
+	if _, err := engine.Command("boardsize", strconv.Itoa(int(size))); err != nil {
+		return err
+	}
+
+	engine.board = board
+
+	return nil
+}
+
+// Clear the board
+func (engine *GTPEngine) ClearBoard() error {
+	if _, err := engine.Command("clear_board"); err != nil {
+		return err
+	}
+
+	engine.board.Clear()
+
+	return nil
+}
+
+// Set the komi
+func (engine *GTPEngine) Komi(komi float64) error {
+	_, err := engine.Command("komi", strconv.FormatFloat(komi, 'f', -1, 64))
+
+	return err
+}
+
+// Play the move on the engine and the local board
+func (engine *GTPEngine) Play(move Move) error {
+	if move.Color != BLACK && move.Color != WHITE {
+		return fmt.Errorf("Invalid move color (%d)!", move.Color)
+	}
+
+	// Check the move locally first, so both boards stay the same
+	if err := engine.board.PlayMove(move); err != nil {
+		return err
+	}
+
+	if _, err := engine.Command("play", GTPColorString(move.Color), VertexString(move, engine.board.BoardSize)); err != nil {
+		engine.board.Undo(1)
+		return err
+	}
+
+	return nil
+}
+
+// Let the engine generate a move for color and play it on the local board. Returns ErrResign if the engine resigns
+func (engine *GTPEngine) GenMove(color BoardStatus) (Move, error) {
+	response, err := engine.Command("genmove", GTPColorString(color))
+	if err != nil {
+		return Move{}, err
+	}
+
+	if strings.EqualFold(response, "resign") {
+		return Move{X: 255, Y: 255, Color: color}, ErrResign
+	}
+
+	move, err := ParseVertex(response, engine.board.BoardSize)
+	if err != nil {
+		return Move{}, err
+	}
+
+	move.Color = color
+	if err := engine.board.PlayMove(move); err != nil {
+		return Move{}, fmt.Errorf("Engine played an illegal move %s: %v", response, err)
+	}
+
+	return move, nil
+}
+
+// Take back the last move
+func (engine *GTPEngine) Undo() error {
+	if _, err := engine.Command("undo"); err != nil {
+		return err
+	}
+
+	engine.board.Undo(1)
+
+	return nil
+}
+
+// Let the engine place a fixed handicap and place the same stones on the local board
+func (engine *GTPEngine) FixedHandicap(stones int) ([]Position, error) {
+	return engine.handicap("fixed_handicap", stones)
+}
+
+// Let the engine choose the handicap positions and place the same stones on the local board
+func (engine *GTPEngine) PlaceFreeHandicap(stones int) ([]Position, error) {
+	return engine.handicap("place_free_handicap", stones)
+}
+
+// Place handicap stones at the given positions on the engine and the local board
+func (engine *GTPEngine) SetFreeHandicap(positions []Position) error {
+	vertices := []string{}
+	for _, position := range positions {
+		vertices = append(vertices, VertexString(Move{X: position.X, Y: position.Y}, engine.board.BoardSize))
+	}
+
+	if _, err := engine.Command("set_free_handicap", vertices...); err != nil {
+		return err
+	}
+
+	return engine.board.SetFreeHandicap(positions)
+}
+
+// Sends a handicap command and places the returned vertices on the local board
+func (engine *GTPEngine) handicap(command string, stones int) ([]Position, error) {
+	response, err := engine.Command(command, strconv.Itoa(stones))
+	if err != nil {
+		return nil, err
+	}
+
+	positions := []Position{}
+	for _, vertex := range strings.Fields(response) {
+		move, err := ParseVertex(vertex, engine.board.BoardSize)
+		if err != nil || move.IsPass() {
+			return nil, fmt.Errorf("Invalid handicap vertex %q!", vertex)
+		}
+
+		positions = append(positions, Position{move.X, move.Y})
+	}
+
+	if err := engine.board.SetFreeHandicap(positions); err != nil {
+		return nil, err
+	}
+
+	return positions, nil
+}
+
+// Score reported by an engine, Winner is EMPTY for a draw
+type GTPScore struct {
+	Winner BoardStatus
+	Margin float64
+}
+
+// Returns the score the engine counts
+func (engine *GTPEngine) FinalScore() (GTPScore, error) {
+	response, err := engine.Command("final_score")
+	if err != nil {
+		return GTPScore{}, err
+	}
+
+	return parseGTPScore(response)
+}
+
+// Parses a final_score response (e.g. "W+3.5" or "0" for a draw)
+func parseGTPScore(score string) (GTPScore, error) {
+	if score == "0" {
+		return GTPScore{EMPTY, 0}, nil
+	}
+
+	if len(score) < 3 || score[1] != '+' {
+		return GTPScore{}, fmt.Errorf("Invalid score %q!", score)
+	}
+
+	margin, err := strconv.ParseFloat(score[2:], 64)
+	if err != nil || margin <= 0 {
+		return GTPScore{}, fmt.Errorf("Invalid score %q!", score)
+	}
+
+	switch score[0] {
+	case 'B':
+		return GTPScore{BLACK, margin}, nil
+	case 'W':
+		return GTPScore{WHITE, margin}, nil
+	}
+
+	return GTPScore{}, fmt.Errorf("Invalid score %q!", score)
+}
+
+// Send quit to the engine and release its resources
+func (engine *GTPEngine) Close() error {
+	_, err := engine.Command("quit")
+
+	if engine.closer != nil {
+		engine.closer.Close()
+	}
+
+	if engine.cmd != nil {
+		if waitErr := engine.cmd.Wait(); err == nil {
+			err = waitErr
+		}
+	}
+
+	return err
+}
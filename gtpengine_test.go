@@ -0,0 +1,105 @@
+package libaduk
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+// Connects the pipes of the fake engine
+type enginePipes struct {
+	io.Reader
+	io.Writer
+}
+
+// Starts a GTP server in the background that acts as engine
+func newFakeEngine() (*GTPEngine, *GTPServer) {
+	commandReader, commandWriter := io.Pipe()
+	responseReader, responseWriter := io.Pipe()
+	server := NewGTPServer("fake", "1.0", firstEmptyGenerator{})
+
+	go func() {
+		server.Serve(commandReader, responseWriter)
+		responseWriter.Close()
+	}()
+
+	return NewGTPEngine(enginePipes{responseReader, commandWriter}), server
+}
+
+// Tests the typed helpers against a fake engine
+func TestGTPEngineGame(t *testing.T) {
+	engine, server := newFakeEngine()
+
+	if name, err := engine.Name(); err != nil || name != "fake" {
+		t.Errorf("Name should be fake but was %q (%+v)", name, err)
+	}
+
+	if err := engine.BoardSize(5); err != nil {
+		t.Fatalf("Boardsize should be accepted but was %+v", err)
+	}
+	engine.Komi(0.5)
+
+	if err := engine.Play(Move{X: 2, Y: 2, Color: BLACK}); err != nil {
+		t.Errorf("Move should be legal but was %+v", err)
+	}
+
+	move, err := engine.GenMove(WHITE)
+	if err != nil || move.X != 0 || move.Y != 0 {
+		t.Errorf("Engine should play A5 but was %+v (%+v)", move, err)
+	}
+
+	if engine.Board().GetHash() != server.Board().GetHash() || engine.Board().getStatus(0, 0) != WHITE {
+		t.Errorf("Boards should be in sync but were:\n%s\n%s", engine.Board().ToString(), server.Board().ToString())
+	}
+
+	if score, err := engine.FinalScore(); err != nil || score != (GTPScore{WHITE, 0.5}) {
+		t.Errorf("Score should be W+0.50 but was %+v (%+v)", score, err)
+	}
+
+	engine.Undo()
+	if engine.Board().getStatus(0, 0) != EMPTY || server.Board().getStatus(0, 0) != EMPTY {
+		t.Errorf("Undo should remove the last move on both boards!")
+	}
+
+	if err := engine.Close(); err != nil {
+		t.Errorf("Close should succeed but was %+v", err)
+	}
+}
+
+// Tests if illegal moves and error responses are returned as error
+func TestGTPEngineErrors(t *testing.T) {
+	engine, _ := newFakeEngine()
+	defer engine.Close()
+
+	engine.BoardSize(5)
+	engine.Play(Move{X: 2, Y: 2, Color: BLACK})
+
+	if err := engine.Play(Move{X: 2, Y: 2, Color: WHITE}); err == nil {
+		t.Errorf("Playing on an occupied position should fail!")
+	}
+
+	if _, err := engine.Command("unknown_command"); err == nil || !strings.Contains(err.Error(), "unknown command") {
+		t.Errorf("Unknown command should return the engine error but was %+v", err)
+	}
+
+	// Response of the server with multiple lines
+	if response, err := engine.Command("showboard"); err != nil || strings.Count(response, "\n") != 5 {
+		t.Errorf("Showboard should return 6 lines but was %q (%+v)", response, err)
+	}
+}
+
+// Tests parsing of final_score responses
+func TestParseGTPScore(t *testing.T) {
+	valid := map[string]GTPScore{"B+3.5": {BLACK, 3.5}, "W+0.50": {WHITE, 0.5}, "0": {EMPTY, 0}}
+	for response, expected := range valid {
+		if score, err := parseGTPScore(response); err != nil || score != expected {
+			t.Errorf("Score %q should be %+v but was %+v (%+v)", response, expected, score, err)
+		}
+	}
+
+	for _, response := range []string{"", "B+", "X+3", "W3.5", "B+abc", "B+0", "W+-2"} {
+		if score, err := parseGTPScore(response); err == nil {
+			t.Errorf("Score %q should be invalid but was %+v", response, score)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package libaduk
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Returns the fixed handicap positions of the GTP specification for the given board size and number of stones.
+// Boards with an odd size from 9 on allow 2 to 9 stones, all other boards from 7 on 2 to 4 stones
+func FixedHandicapPositions(boardSize uint8, stones int) ([]Position, error) {
+	maxStones := 4
+	if boardSize%2 == 1 && boardSize >= 9 {
+		maxStones = 9
+	}
+
+	if boardSize < 7 || boardSize > 25 || stones < 2 || stones > maxStones {
+		return nil, fmt.Errorf("Invalid fixed handicap of %d stones on %dx%d!", stones, boardSize, boardSize)
+	}
+
+	// Star points are on the third line for small boards and on the fourth line otherwise
+	edge := uint8(2)
+	if boardSize >= 13 {
+		edge = 3
+	}
+	low, high, middle := edge, boardSize-1-edge, boardSize/2
+
+	// D4 Q16 D16 Q4 D10 Q10 K4 K16 in the order of the GTP specification, K10 is added for odd numbers above 3
+	order := []Position{{low, high}, {high, low}, {low, low}, {high, high}, {low, middle}, {high, middle}, {middle, high}, {middle, low}}
+
+	if stones > 4 && stones%2 == 1 {
+		return append(order[:stones-1:stones-1], Position{middle, middle}), nil
+	}
+
+	return order[:stones], nil
+}
+
+// Returns positions for a free handicap of 2 up to the number of points minus one stones.
+// The fixed handicap positions are used as far as possible, further stones are placed closest to the center first
+func FreeHandicapPositions(boardSize uint8, stones int) ([]Position, error) {
+	points := int(boardSize) * int(boardSize)
+	if stones < 2 || stones >= points {
+		return nil, fmt.Errorf("Invalid free handicap of %d stones on %dx%d!", stones, boardSize, boardSize)
+	}
+
+	positions := []Position{}
+	for fixed := stones; fixed >= 2; fixed-- {
+		if fixedPositions, err := FixedHandicapPositions(boardSize, fixed); err == nil {
+			positions = append(positions, fixedPositions...)
+			break
+		}
+	}
+
+	// Distances are doubled, so the center of boards with an even size is on a whole number
+	distance := func(position Position) int {
+		dx, dy := 2*int(position.X)-int(boardSize)+1, 2*int(position.Y)-int(boardSize)+1
+		return dx*dx + dy*dy
+	}
+
+	candidates := []Position{}
+	for x := uint8(0); x < boardSize; x++ {
+		for y := uint8(0); y < boardSize; y++ {
+			if !containsPosition(positions, Position{x, y}) {
+				candidates = append(candidates, Position{x, y})
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return distance(candidates[i]) < distance(candidates[j])
+	})
+
+	return append(positions, candidates[:stones-len(positions)]...), nil
+}
+
+// Place a fixed handicap on the empty board. Returns the positions of the handicap stones
+func (board *AbstractBoard) SetFixedHandicap(stones int) ([]Position, error) {
+	positions, err := FixedHandicapPositions(board.BoardSize, stones)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := board.SetFreeHandicap(positions); err != nil {
+		return nil, err
+	}
+
+	return positions, nil
+}
+
+// Place black handicap stones at the given positions of the empty board
+func (board *AbstractBoard) SetFreeHandicap(positions []Position) error {
+	if len(board.undoStack) > 0 || !board.isEmpty() {
+		return fmt.Errorf("Handicap can only be placed on an empty board!")
+	}
+
+	if len(positions) < 2 || len(positions) >= len(board.data) {
+		return fmt.Errorf("Invalid number of handicap stones (%d)!", len(positions))
+	}
+
+	for i, position := range positions {
+		if position.X >= board.BoardSize || position.Y >= board.BoardSize {
+			return fmt.Errorf("Invalid handicap position (%d, %d)!", position.X, position.Y)
+		}
+
+		if containsPosition(positions[:i], position) {
+			return fmt.Errorf("Duplicate handicap position (%d, %d)!", position.X, position.Y)
+		}
+	}
+
+	for _, position := range positions {
+		board.Setup(position.X, position.Y, BLACK)
+	}
+	board.handicap = len(positions)
+
+	return nil
+}
+
+// Returns the number of handicap stones on the board
+func (board *AbstractBoard) Handicap() int {
+	return board.handicap
+}
+
+// Returns the color that moves next. White moves first after handicap stones were placed
+func (board *AbstractBoard) NextColor() BoardStatus {
+	for i := len(board.history) - 1; i >= 0; i-- {
+		if board.history[i].mover != EMPTY {
+			return board.history[i].mover.invert()
+		}
+	}
+
+	if board.handicap > 0 {
+		return WHITE
+	}
+
+	return BLACK
+}
+
+// Returns the points white gets for the handicap stones according to the rules
+func (board *AbstractBoard) handicapCompensation() int {
+	switch {
+	case board.handicap == 0:
+		return 0
+	case board.rules.HandicapCompensation == HANDICAP_COMPENSATION_FULL:
+		return board.handicap
+	case board.rules.HandicapCompensation == HANDICAP_COMPENSATION_MINUS_ONE:
+		return board.handicap - 1
+	}
+
+	return 0
+}
+
+// Checks if there is no stone on the board
+func (board *AbstractBoard) isEmpty() bool {
+	for _, status := range board.data {
+		if status != EMPTY {
+			return false
+		}
+	}
+
+	return true
+}
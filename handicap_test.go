@@ -0,0 +1,219 @@
+package libaduk
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Tests the fixed handicap positions for the common board sizes
+func TestFixedHandicapPositions(t *testing.T) {
+	tests := []struct {
+		size     uint8
+		stones   int
+		vertices string
+	}{
+		{19, 2, "D4 Q16"},
+		{19, 5, "D4 Q16 D16 Q4 K10"},
+		{19, 9, "D4 Q16 D16 Q4 D10 Q10 K4 K16 K10"},
+		{13, 4, "D4 K10 D10 K4"},
+		{9, 3, "C3 G7 C7"},
+		{9, 6, "C3 G7 C7 G3 C5 G5"},
+	}
+
+	for _, test := range tests {
+		positions, err := FixedHandicapPositions(test.size, test.stones)
+		if err != nil {
+			t.Errorf("Handicap %d on %d should be valid but was %+v", test.stones, test.size, err)
+			continue
+		}
+
+		vertices := []string{}
+		for _, position := range positions {
+			vertices = append(vertices, VertexString(Move{X: position.X, Y: position.Y}, test.size))
+		}
+
+		if strings.Join(vertices, " ") != test.vertices {
+			t.Errorf("Handicap %d on %d should be %s but was %s", test.stones, test.size, test.vertices, strings.Join(vertices, " "))
+		}
+	}
+
+	for _, invalid := range []struct {
+		size   uint8
+		stones int
+	}{{19, 1}, {19, 10}, {10, 5}, {5, 2}} {
+		if _, err := FixedHandicapPositions(invalid.size, invalid.stones); err == nil {
+			t.Errorf("Handicap %d on %d should be invalid!", invalid.stones, invalid.size)
+		}
+	}
+}
+
+// Tests if free handicaps of every valid number of stones can be placed
+func TestFreeHandicapPositions(t *testing.T) {
+	positions, err := FreeHandicapPositions(19, 12)
+	if err != nil || len(positions) != 12 {
+		t.Fatalf("Handicap 12 on 19 should be valid but was %+v (%+v)", positions, err)
+	}
+
+	// The fixed positions come first
+	if fixed, _ := FixedHandicapPositions(19, 9); !reflect.DeepEqual(positions[:9], fixed) {
+		t.Errorf("Handicap should start with %+v but was %+v", fixed, positions[:9])
+	}
+
+	for _, size := range []uint8{2, 5, 10, 19} {
+		points := int(size) * int(size)
+		for stones := 2; stones < points; stones++ {
+			positions, err := FreeHandicapPositions(size, stones)
+			board, _ := NewBoard(size)
+			if err != nil || board.SetFreeHandicap(positions) != nil {
+				t.Fatalf("Handicap %d on %d should be valid but was %+v (%+v)", stones, size, positions, err)
+			}
+		}
+
+		for _, stones := range []int{1, points} {
+			if _, err := FreeHandicapPositions(size, stones); err == nil {
+				t.Errorf("Handicap %d on %d should be invalid!", stones, size)
+			}
+		}
+	}
+}
+
+// Tests placing the handicap on the board, white moves first afterwards
+func TestSetHandicap(t *testing.T) {
+	board, _ := NewBoard(9)
+
+	if board.NextColor() != BLACK {
+		t.Errorf("Black should move first without handicap!")
+	}
+
+	if _, err := board.SetFixedHandicap(4); err != nil {
+		t.Fatalf("Handicap should be placed but was %+v", err)
+	}
+
+	if board.Handicap() != 4 || board.NextColor() != WHITE || board.getStatus(2, 6) != BLACK {
+		t.Errorf("Board should have 4 handicap stones and white to move:\n%s", board.ToString())
+	}
+
+	if _, err := board.SetFixedHandicap(2); err == nil {
+		t.Errorf("Handicap should not be placed on a board with stones!")
+	}
+
+	board.Play(4, 4, WHITE)
+	if board.NextColor() != BLACK {
+		t.Errorf("Black should move after white!")
+	}
+
+	board.Undo(2)
+	if board.Handicap() != 3 {
+		t.Errorf("Undo should remove a handicap stone but was %d", board.Handicap())
+	}
+
+	board.Clear()
+	for _, positions := range [][]Position{{{0, 0}}, {{0, 0}, {0, 0}}, {{0, 0}, {9, 0}}} {
+		if err := board.SetFreeHandicap(positions); err == nil {
+			t.Errorf("Free handicap %+v should be invalid!", positions)
+		}
+	}
+
+	if err := board.SetFreeHandicap([]Position{{0, 0}, {8, 8}, {4, 4}}); err != nil || board.Handicap() != 3 {
+		t.Errorf("Free handicap should be placed but was %+v", err)
+	}
+}
+
+// Tests if white gets the handicap compensation of the rules
+func TestScoreHandicapCompensation(t *testing.T) {
+	for _, test := range []struct {
+		rules Ruleset
+		white float64
+	}{{JapaneseRules, 0.5}, {ChineseRules, 2.5}, {AGARules, 1.5}} {
+		board, _ := NewBoardWithRules(9, test.rules)
+		board.SetFixedHandicap(2)
+
+		result, _ := board.Score(nil, 0.5)
+		if result.White != test.white {
+			t.Errorf("White should have %.1f points with %s rules but was %.1f", test.white, test.rules.Name, result.White)
+		}
+	}
+}
+
+// Tests if HA and AB of the root node place the handicap during replay
+func TestBoardCursorHandicap(t *testing.T) {
+	cursor, _ := NewBoardCursor([]byte("(;SZ[9]HA[3]AB[aa][bb][cc];W[ee])"))
+	cursor.Next(0)
+
+	board := cursor.Board()
+	if board.Handicap() != 3 || board.getStatus(1, 1) != BLACK || board.getStatus(4, 4) != WHITE || board.NextColor() != BLACK {
+		t.Errorf("Handicap should be placed at AB:\n%s", board.ToString())
+	}
+
+	// HA is informational, the handicap stones can be played as moves
+	cursor, err := NewBoardCursor([]byte("(;SZ[9]HA[2];B[cg];B[gc];W[ee])"))
+	if err != nil {
+		t.Fatalf("Game should be replayed but was %+v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := cursor.Next(0); err != nil {
+			t.Fatalf("Handicap moves should be played but was %+v", err)
+		}
+	}
+
+	board = cursor.Board()
+	if board.Handicap() != 0 || board.getStatus(2, 6) != BLACK || board.getStatus(4, 4) != WHITE {
+		t.Errorf("No handicap should be placed without AB:\n%s", board.ToString())
+	}
+
+	for _, sgf := range []string{"(;SZ[9]HA[]AB[aa][bb];W[ee])", "(;SZ[9]HA[two]AB[aa][bb];W[ee])"} {
+		cursor, err := NewBoardCursor([]byte(sgf))
+		if err != nil || cursor.Board().Handicap() != 0 || cursor.Board().getStatus(1, 1) != BLACK {
+			t.Errorf("Malformed HA of %s should be ignored but was %+v", sgf, err)
+		}
+	}
+}
+
+// Tests if a pass after the handicap is made by white
+func TestPassAfterHandicap(t *testing.T) {
+	board, _ := NewBoard(9)
+	board.SetFixedHandicap(2)
+	board.UndostackPushPass()
+
+	if board.history[len(board.history)-1].mover != WHITE || board.NextColor() != BLACK {
+		t.Errorf("Pass after the handicap should be made by white!")
+	}
+
+	board.UndostackPushPass()
+	if board.NextColor() != WHITE {
+		t.Errorf("White should move after the pass of black!")
+	}
+}
+
+// Tests the GTP handicap commands
+func TestGTPHandicap(t *testing.T) {
+	output := runGTP(
+		"1 boardsize 9",
+		"2 fixed_handicap 3",
+		"3 fixed_handicap 2",
+		"4 undo",
+		"5 clear_board",
+		"6 set_free_handicap A1 J9",
+		"7 clear_board",
+		"8 place_free_handicap 10",
+		"9 boardsize 5",
+		"10 place_free_handicap 25",
+	)
+
+	expected := "=1 \n\n=2 C3 G7 C7\n\n?3 board not empty\n\n?4 cannot undo\n\n=5 \n\n=6 \n\n=7 \n\n=8 C3 G7 C7 G3 C5 G5 E3 E7 E5 D5\n\n=9 \n\n?10 invalid number of stones\n\n"
+	if output != expected {
+		t.Errorf("GTP output should be %q but was %q", expected, output)
+	}
+
+	engine, server := newFakeEngine()
+	engine.BoardSize(9)
+
+	positions, err := engine.PlaceFreeHandicap(2)
+	if err != nil || len(positions) != 2 || engine.Board().GetHash() != server.Board().GetHash() || engine.Board().NextColor() != WHITE {
+		t.Errorf("Handicap should be placed on both boards but was %+v (%+v)", positions, err)
+	}
+
+	engine.Close()
+}
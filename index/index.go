@@ -0,0 +1,257 @@
+// Package index builds a position index over a corpus of SGF games.
+//
+// Every game is replayed with a libaduk board cursor and the symmetry canonical
+// Zobrist hash is recorded after every move of the main line. The index answers
+// which games reached a position (up to symmetry), what was played next and how
+// these games ended.
+package index
+
+import (
+	"encoding/gob"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/Beldur/libaduk"
+)
+
+// Information about an indexed game
+type GameInfo struct {
+	Name   string // Name of the source (e.g. the file name)
+	Black  string
+	White  string
+	Result string // SGF RE value
+}
+
+// Returns the winner of the game according to its result or EMPTY if it's unknown
+func (info GameInfo) Winner() libaduk.BoardStatus {
+	result := strings.ToUpper(strings.TrimSpace(info.Result))
+
+	if strings.HasPrefix(result, "B+") {
+		return libaduk.BLACK
+	}
+
+	if strings.HasPrefix(result, "W+") {
+		return libaduk.WHITE
+	}
+
+	return libaduk.EMPTY
+}
+
+// A game that reached the searched position
+type Hit struct {
+	Game       int          // Index of the game in Index.Games
+	MoveNumber int          // Number of moves played when the position was reached
+	Next       libaduk.Move // Move played next, in the orientation of the searched position
+	HasNext    bool         // False if the game ended in this position
+}
+
+// A move played in the searched position with statistics about the games
+type Continuation struct {
+	Move      libaduk.Move
+	Count     int
+	BlackWins int
+	WhiteWins int
+	Games     []int
+}
+
+// Position index over a corpus of games
+type Index struct {
+	Games     []GameInfo
+	Positions map[uint64][]Entry
+}
+
+// A position reached in a game, the next move is stored in canonical orientation
+type Entry struct {
+	Game       int32
+	MoveNumber int32
+	NextX      uint8
+	NextY      uint8
+	NextColor  libaduk.BoardStatus
+	BoardSize  uint8
+}
+
+// Create a new empty index
+func New() *Index {
+	return &Index{Games: []GameInfo{}, Positions: map[uint64][]Entry{}}
+}
+
+// Load an index that was written with Save
+func Load(r io.Reader) (*Index, error) {
+	index := New()
+
+	if err := gob.NewDecoder(r).Decode(index); err != nil {
+		return nil, err
+	}
+
+	return index, nil
+}
+
+// Write the index to w
+func (index *Index) Save(w io.Writer) error {
+	return gob.NewEncoder(w).Encode(index)
+}
+
+// Add all games of the given sgf file
+func (index *Index) AddFile(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return index.AddGames(path, data)
+}
+
+// Add all games of the sgf collection, the main line of every game is indexed.
+// A game is indexed up to its first illegal move
+func (index *Index) AddGames(name string, sgf []byte) error {
+	cursor, err := libaduk.NewBoardCursor(sgf)
+	if err != nil {
+		return err
+	}
+
+	for n := 0; n < cursor.NumGames(); n++ {
+		root, err := cursor.Game(n)
+		if err != nil {
+			return err
+		}
+
+		game := int32(len(index.Games))
+		index.Games = append(index.Games, GameInfo{
+			Name:   name,
+			Black:  root.PropertyValue("PB"),
+			White:  root.PropertyValue("PW"),
+			Result: root.PropertyValue("RE"),
+		})
+
+		for moveNumber := int32(0); ; {
+			board := cursor.Board()
+			hash, sym := board.CanonicalHash()
+			entry := Entry{Game: game, MoveNumber: moveNumber, NextX: 255, NextY: 255, BoardSize: board.BoardSize}
+
+			// Remember the next move in canonical orientation
+			node := cursor.Current().Next
+			var move *libaduk.Move
+			if node != nil {
+				move, _ = node.Move(board.BoardSize)
+			}
+
+			if move != nil {
+				next := move.Transform(sym, board.BoardSize)
+				entry.NextX, entry.NextY, entry.NextColor = next.X, next.Y, next.Color
+			}
+
+			// Nodes without moves (e.g. comments) don't add a new position
+			if move != nil || node == nil {
+				index.Positions[hash] = append(index.Positions[hash], entry)
+			}
+
+			if node == nil {
+				break
+			}
+
+			if _, err := cursor.Next(0); err != nil {
+				break
+			}
+
+			if move != nil {
+				moveNumber++
+			}
+		}
+	}
+
+	return nil
+}
+
+// Returns all games that reached the position of board (up to symmetry)
+func (index *Index) Search(board *libaduk.AbstractBoard) []Hit {
+	hash, sym := board.CanonicalHash()
+	hits := []Hit{}
+
+	for _, entry := range index.Positions[hash] {
+		if entry.BoardSize != board.BoardSize {
+			continue
+		}
+
+		hit := Hit{Game: int(entry.Game), MoveNumber: int(entry.MoveNumber), HasNext: entry.NextColor != libaduk.EMPTY}
+		if hit.HasNext {
+			next := libaduk.Move{X: entry.NextX, Y: entry.NextY, Color: entry.NextColor}
+			hit.Next = next.Transform(sym.Inverse(), board.BoardSize)
+		}
+
+		hits = append(hits, hit)
+	}
+
+	return hits
+}
+
+// Returns the moves played in the position of board, the most frequent first. Moves that are the same up to a
+// symmetry of the position are merged, the move of the first game is returned
+func (index *Index) Continuations(board *libaduk.AbstractBoard) []Continuation {
+	byMove := map[libaduk.Position]*Continuation{}
+	continuations := []*Continuation{}
+	symmetries := positionSymmetries(board)
+
+	for _, hit := range index.Search(board) {
+		if !hit.HasNext {
+			continue
+		}
+
+		position := canonicalPosition(libaduk.Position{X: hit.Next.X, Y: hit.Next.Y}, symmetries, board.BoardSize)
+		continuation, ok := byMove[position]
+		if !ok {
+			continuation = &Continuation{Move: hit.Next}
+			byMove[position] = continuation
+			continuations = append(continuations, continuation)
+		}
+
+		continuation.Count++
+		continuation.Games = append(continuation.Games, hit.Game)
+
+		switch index.Games[hit.Game].Winner() {
+		case libaduk.BLACK:
+			continuation.BlackWins++
+		case libaduk.WHITE:
+			continuation.WhiteWins++
+		}
+	}
+
+	sort.SliceStable(continuations, func(i, j int) bool {
+		return continuations[i].Count > continuations[j].Count
+	})
+
+	result := make([]Continuation, len(continuations))
+	for i, continuation := range continuations {
+		result[i] = *continuation
+	}
+
+	return result
+}
+
+// Returns the symmetries that don't change the position of board
+func positionSymmetries(board *libaduk.AbstractBoard) []libaduk.Symmetry {
+	symmetries := []libaduk.Symmetry{}
+
+	for _, sym := range libaduk.Symmetries {
+		if board.Transform(sym).GetHash() == board.GetHash() {
+			symmetries = append(symmetries, sym)
+		}
+	}
+
+	return symmetries
+}
+
+// Returns the smallest of the positions position is transformed to by the symmetries
+func canonicalPosition(position libaduk.Position, symmetries []libaduk.Symmetry, boardSize uint8) libaduk.Position {
+	canonical := position
+
+	for _, sym := range symmetries {
+		transformed := position.Transform(sym, boardSize)
+		if transformed.Y < canonical.Y || (transformed.Y == canonical.Y && transformed.X < canonical.X) {
+			canonical = transformed
+		}
+	}
+
+	return canonical
+}
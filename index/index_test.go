@@ -0,0 +1,127 @@
+package index
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Beldur/libaduk"
+)
+
+// Creates an index of the test games
+func newTestIndex(t *testing.T) *Index {
+	index := New()
+
+	for _, file := range []string{"../testing/Small.sgf", "../testing/Easy.sgf", "../testing/Batora-okao.sgf"} {
+		if err := index.AddFile(file); err != nil {
+			t.Fatalf("%s should be indexed but was %+v", file, err)
+		}
+	}
+
+	return index
+}
+
+// Tests searching a position that was reached in a different orientation
+func TestSearchUpToSymmetry(t *testing.T) {
+	index := newTestIndex(t)
+	board, _ := libaduk.NewBoard(9)
+
+	// B[gc] W[cg] rotated by 90 degrees
+	for _, move := range []libaduk.Move{{X: 6, Y: 2, Color: libaduk.BLACK}, {X: 2, Y: 6, Color: libaduk.WHITE}} {
+		rotated := move.Transform(libaduk.ROTATE_90, 9)
+		board.PlayMove(rotated)
+	}
+
+	hits := index.Search(board)
+	if len(hits) != 2 {
+		t.Fatalf("Position should be found in Small.sgf and Easy.sgf but was %+v", hits)
+	}
+
+	// Small.sgf continues with B[gg], Easy.sgf with B[cc]
+	expected := map[libaduk.Position]bool{
+		libaduk.Position{X: 6, Y: 6}.Transform(libaduk.ROTATE_90, 9): true,
+		libaduk.Position{X: 2, Y: 2}.Transform(libaduk.ROTATE_90, 9): true,
+	}
+
+	for _, hit := range hits {
+		if hit.MoveNumber != 2 || !hit.HasNext || !expected[libaduk.Position{X: hit.Next.X, Y: hit.Next.Y}] {
+			t.Errorf("Unexpected hit %+v", hit)
+		}
+	}
+}
+
+// Tests the continuations of the empty board
+func TestContinuations(t *testing.T) {
+	index := newTestIndex(t)
+	board, _ := libaduk.NewBoard(9)
+
+	continuations := index.Continuations(board)
+	if len(continuations) != 2 {
+		t.Fatalf("Empty board should have 2 different continuations but was %+v", continuations)
+	}
+
+	// B[gc] was played in Small.sgf and Easy.sgf, B[gd] in Batora-okao.sgf that white won
+	if continuations[0].Count != 2 || continuations[1].Count != 1 || continuations[1].WhiteWins != 1 {
+		t.Errorf("Unexpected continuations %+v", continuations)
+	}
+
+	if index.Games[continuations[1].Games[0]].Black != "okao" {
+		t.Errorf("Game info should be stored but was %+v", index.Games)
+	}
+}
+
+// Tests that continuations which are the same up to a symmetry of the position are merged
+func TestContinuationsUpToSymmetry(t *testing.T) {
+	index := New()
+	index.AddGames("games", []byte("(;SZ[9]RE[B+R];B[gc])(;SZ[9]RE[W+R];B[cg])(;SZ[9];B[gd])"))
+	board, _ := libaduk.NewBoard(9)
+
+	continuations := index.Continuations(board)
+	if len(continuations) != 2 || continuations[0].Count != 2 || continuations[0].Move.X != 6 || continuations[0].Move.Y != 2 {
+		t.Fatalf("B[gc] and B[cg] should be merged but was %+v", continuations)
+	}
+
+	if continuations[0].BlackWins != 1 || continuations[0].WhiteWins != 1 {
+		t.Errorf("Merged continuation should count both results but was %+v", continuations[0])
+	}
+
+	// With a stone on the diagonal only the mirror symmetry remains
+	board.Play(2, 2, libaduk.BLACK)
+	if symmetries := positionSymmetries(board); len(symmetries) != 2 {
+		t.Errorf("Position should have 2 symmetries but had %+v", symmetries)
+	}
+}
+
+// Tests saving and loading an index
+func TestSaveAndLoad(t *testing.T) {
+	index := newTestIndex(t)
+
+	var buffer bytes.Buffer
+	if err := index.Save(&buffer); err != nil {
+		t.Fatalf("Index should be saved but was %+v", err)
+	}
+
+	loaded, err := Load(&buffer)
+	if err != nil {
+		t.Fatalf("Index should be loaded but was %+v", err)
+	}
+
+	board, _ := libaduk.NewBoard(9)
+	if len(loaded.Games) != len(index.Games) || len(loaded.Search(board)) != len(index.Search(board)) {
+		t.Errorf("Loaded index should be the same as the saved one!")
+	}
+}
+
+// Tests if empty files are reported as error instead of being indexed
+func TestAddEmptyGames(t *testing.T) {
+	index := New()
+
+	for _, sgf := range []string{"", " \n\t"} {
+		if err := index.AddGames("empty", []byte(sgf)); err == nil {
+			t.Errorf("Adding %q should fail!", sgf)
+		}
+	}
+
+	if len(index.Games) != 0 {
+		t.Errorf("Index should have no games but had %d", len(index.Games))
+	}
+}
@@ -0,0 +1,179 @@
+package index
+
+import (
+	"io/ioutil"
+	"sort"
+
+	"github.com/Beldur/libaduk"
+)
+
+// A node of a game where the pattern matched
+type PatternHit struct {
+	Game       int // Index of the game in PatternSearch.Games
+	MoveNumber int
+	Match      libaduk.PatternMatch
+	Next       []libaduk.Move // Moves played next inside the matched area, in pattern coordinates
+}
+
+// A move played inside the pattern with statistics about the games
+type PatternContinuation struct {
+	Move      libaduk.Move // Position in pattern coordinates
+	Count     int
+	BlackWins int
+	WhiteWins int
+}
+
+// Searches a pattern in all variations of a corpus of games
+type PatternSearch struct {
+	Pattern *libaduk.Pattern
+	Games   []GameInfo
+	Hits    []PatternHit
+}
+
+// Create a new search for the pattern
+func NewPatternSearch(pattern *libaduk.Pattern) *PatternSearch {
+	return &PatternSearch{Pattern: pattern, Games: []GameInfo{}, Hits: []PatternHit{}}
+}
+
+// Search the pattern in all games of the given sgf file
+func (search *PatternSearch) AddFile(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return search.AddGames(path, data)
+}
+
+// Search the pattern in all nodes of all games of the sgf collection. Variations with illegal moves are skipped
+func (search *PatternSearch) AddGames(name string, sgf []byte) error {
+	cursor, err := libaduk.NewBoardCursor(sgf)
+	if err != nil {
+		return err
+	}
+
+	for n := 0; n < cursor.NumGames(); n++ {
+		root, err := cursor.Game(n)
+		if err != nil {
+			return err
+		}
+
+		game := len(search.Games)
+		search.Games = append(search.Games, GameInfo{
+			Name:   name,
+			Black:  root.PropertyValue("PB"),
+			White:  root.PropertyValue("PW"),
+			Result: root.PropertyValue("RE"),
+		})
+
+		// Depth first search, children holds the index of the next child to visit for every depth
+		search.visit(cursor, game, 0)
+		children := []int{0}
+		moveNumbers := []int{0}
+
+		for len(children) > 0 {
+			depth := len(children) - 1
+			child := children[depth]
+			children[depth]++
+
+			if child >= countChildren(cursor.Current()) {
+				cursor.Previous()
+				children = children[:depth]
+				moveNumbers = moveNumbers[:depth]
+				continue
+			}
+
+			node, err := cursor.Next(child)
+			if err != nil {
+				continue
+			}
+
+			moveNumber := moveNumbers[depth]
+			if move, _ := node.Move(cursor.Board().BoardSize); move != nil {
+				moveNumber++
+			}
+
+			search.visit(cursor, game, moveNumber)
+			children = append(children, 0)
+			moveNumbers = append(moveNumbers, moveNumber)
+		}
+	}
+
+	return nil
+}
+
+// Records the matches of the pattern at the current node of the cursor
+func (search *PatternSearch) visit(cursor *libaduk.Cursor, game int, moveNumber int) {
+	board := cursor.Board()
+	matches := board.Match(search.Pattern)
+
+	for _, match := range matches {
+		hit := PatternHit{Game: game, MoveNumber: moveNumber, Match: match, Next: []libaduk.Move{}}
+
+		for child := cursor.Current().Next; child != nil; child = child.Down {
+			move, _ := child.Move(board.BoardSize)
+			if move == nil || move.IsPass() {
+				continue
+			}
+
+			if position, ok := search.Pattern.PatternPosition(match, libaduk.Position{X: move.X, Y: move.Y}); ok {
+				hit.Next = append(hit.Next, libaduk.Move{X: position.X, Y: position.Y, Color: move.Color})
+			}
+		}
+
+		search.Hits = append(search.Hits, hit)
+	}
+}
+
+// Returns all moves played inside the pattern, the most frequent first
+func (search *PatternSearch) Continuations() []PatternContinuation {
+	type key struct {
+		position libaduk.Position
+		color    libaduk.BoardStatus
+	}
+
+	byMove := map[key]*PatternContinuation{}
+	continuations := []*PatternContinuation{}
+
+	for _, hit := range search.Hits {
+		for _, move := range hit.Next {
+			k := key{libaduk.Position{X: move.X, Y: move.Y}, move.Color}
+			continuation, ok := byMove[k]
+			if !ok {
+				continuation = &PatternContinuation{Move: move}
+				byMove[k] = continuation
+				continuations = append(continuations, continuation)
+			}
+
+			continuation.Count++
+			switch search.Games[hit.Game].Winner() {
+			case libaduk.BLACK:
+				continuation.BlackWins++
+			case libaduk.WHITE:
+				continuation.WhiteWins++
+			}
+		}
+	}
+
+	sort.SliceStable(continuations, func(i, j int) bool {
+		return continuations[i].Count > continuations[j].Count
+	})
+
+	result := make([]PatternContinuation, len(continuations))
+	for i, continuation := range continuations {
+		result[i] = *continuation
+	}
+
+	return result
+}
+
+// Returns the number of children of the node
+func countChildren(node *libaduk.Node) int {
+	count := 0
+
+	for child := node.Next; child != nil; child = child.Down {
+		count++
+	}
+
+	return count
+}
@@ -0,0 +1,71 @@
+package index
+
+import (
+	"testing"
+
+	"github.com/Beldur/libaduk"
+)
+
+// Corner pattern with a stone on the 4-4 point
+func cornerPattern() *libaduk.Pattern {
+	pattern, _ := libaduk.ParsePattern(
+		"#######",
+		"#......",
+		"#......",
+		"#......",
+		"#...X..",
+		"#......",
+		"#......",
+	)
+
+	return pattern
+}
+
+// Tests searching the corner 4-4 point in Kogo's joseki dictionary
+func TestPatternSearchJosekiDictionary(t *testing.T) {
+	search := NewPatternSearch(cornerPattern())
+
+	if err := search.AddFile("../testing/Kogo's Joseki Dictionary.sgf"); err != nil {
+		t.Fatalf("Dictionary should be searched but was %+v", err)
+	}
+
+	continuations := search.Continuations()
+	if len(search.Hits) == 0 || len(continuations) == 0 {
+		t.Fatalf("Pattern should be found with continuations!")
+	}
+
+	// The 3-3 invasion has to be one of the continuations
+	found := false
+	for _, continuation := range continuations {
+		if continuation.Move.X == 3 && continuation.Move.Y == 3 && continuation.Move.Color == libaduk.WHITE {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Errorf("3-3 invasion should be a continuation but was %+v", continuations)
+	}
+}
+
+// Tests if empty files are reported as error instead of being searched
+func TestPatternSearchEmptyGames(t *testing.T) {
+	search := NewPatternSearch(cornerPattern())
+
+	for _, sgf := range []string{"", " \n\t"} {
+		if err := search.AddGames("empty", []byte(sgf)); err == nil {
+			t.Errorf("Searching %q should fail!", sgf)
+		}
+	}
+
+	if len(search.Games) != 0 || len(search.Hits) != 0 {
+		t.Errorf("Search should have no games but had %d", len(search.Games))
+	}
+}
+
+func BenchmarkPatternSearchJosekiDictionary(b *testing.B) {
+	pattern := cornerPattern()
+
+	for i := 0; i < b.N; i++ {
+		NewPatternSearch(pattern).AddFile("../testing/Kogo's Joseki Dictionary.sgf")
+	}
+}
@@ -0,0 +1,125 @@
+package libaduk
+
+import (
+	"errors"
+)
+
+// Rule that decides which board repetitions are forbidden
+type KoRule uint8
+
+const (
+	KO_SIMPLE              KoRule = iota // Only the immediate recapture of a ko is forbidden
+	KO_POSITIONAL_SUPERKO                // No board position may be repeated
+	KO_SITUATIONAL_SUPERKO               // No board position may be repeated with the same player to move
+)
+
+var (
+	ErrKo                 = errors.New("Invalid move (Ko)!")
+	ErrPositionalSuperko  = errors.New("Invalid move (Positional superko)!")
+	ErrSituationalSuperko = errors.New("Invalid move (Situational superko)!")
+)
+
+// A board position after an undostack entry
+type positionHistory struct {
+	before uint64      // Hash before the entry was played
+	after  uint64      // Hash after the entry was played
+	mover  BoardStatus // Player who made the move, EMPTY for setup moves
+	trail  int         // Length of the chain trail after the entry was played
+}
+
+// A board position together with the player who moved last
+type situation struct {
+	hash  uint64
+	mover BoardStatus
+}
+
+// Set the ko rule of the board
+func (board *AbstractBoard) SetKoRule(rule KoRule) {
+	board.rules.KoRule = rule
+}
+
+// Returns the ko rule of the board
+func (board *AbstractBoard) KoRule() KoRule {
+	return board.rules.KoRule
+}
+
+// Resets the position history to the current position
+func (board *AbstractBoard) resetHistory() {
+	board.history = []positionHistory{}
+	board.positions = map[uint64]int{board.GetHash(): 1}
+	board.situations = map[situation]int{}
+}
+
+// Adds the current position after the given move to the history
+func (board *AbstractBoard) pushHistory(move *Move) {
+	before := board.GetHash()
+	mover := EMPTY
+
+	if len(board.history) > 0 {
+		before = board.history[len(board.history)-1].after
+	}
+
+	// A pass is made by the player who has to move
+	if move.Color == PASS {
+		mover = board.NextColor()
+	}
+
+	if !move.Setup && (move.Color == BLACK || move.Color == WHITE) {
+		mover = move.Color
+	}
+
+	entry := positionHistory{before, board.GetHash(), mover, len(board.trail)}
+	board.history = append(board.history, entry)
+	board.positions[entry.after]++
+	board.situations[situation{entry.after, mover}]++
+}
+
+// Removes the last position from the history and restores the chains before the entry
+func (board *AbstractBoard) popHistory() {
+	if len(board.history) == 0 {
+		return
+	}
+
+	entry := board.history[len(board.history)-1]
+	board.history = board.history[:len(board.history)-1]
+
+	if len(board.history) > 0 {
+		board.rollbackTrail(board.history[len(board.history)-1].trail)
+	} else {
+		board.rollbackTrail(0)
+	}
+
+	board.positions[entry.after]--
+	if board.positions[entry.after] <= 0 {
+		delete(board.positions, entry.after)
+	}
+
+	key := situation{entry.after, entry.mover}
+	board.situations[key]--
+	if board.situations[key] <= 0 {
+		delete(board.situations, key)
+	}
+}
+
+// Checks if the current position (after color played and captured stones) violates the ko rule
+func (board *AbstractBoard) checkKo(color BoardStatus, captures []Position) error {
+	hash := board.GetHash()
+
+	// Immediate recapture of a single stone that recreates the position before the last move
+	if len(captures) == 1 && len(board.history) > 0 && board.history[len(board.history)-1].before == hash {
+		return ErrKo
+	}
+
+	switch board.rules.KoRule {
+	case KO_POSITIONAL_SUPERKO:
+		if board.positions[hash] > 0 {
+			return ErrPositionalSuperko
+		}
+	case KO_SITUATIONAL_SUPERKO:
+		if board.situations[situation{hash, color}] > 0 {
+			return ErrSituationalSuperko
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package libaduk
+
+import (
+	"testing"
+)
+
+// Creates a board with a ko shape, white has a stone at (1, 1) that black can capture at (2, 1)
+func newKoBoard(t *testing.T, rule KoRule) *AbstractBoard {
+	board, _ := NewBoard(9)
+	board.SetKoRule(rule)
+
+	moves := []Move{
+		{X: 1, Y: 0, Color: BLACK}, {X: 2, Y: 0, Color: WHITE},
+		{X: 0, Y: 1, Color: BLACK}, {X: 3, Y: 1, Color: WHITE},
+		{X: 1, Y: 2, Color: BLACK}, {X: 2, Y: 2, Color: WHITE},
+		{X: 8, Y: 0, Color: BLACK}, {X: 1, Y: 1, Color: WHITE},
+		{X: 8, Y: 8, Color: BLACK},
+	}
+
+	for _, move := range moves {
+		if err := board.PlayMove(move); err != nil {
+			t.Fatalf("Move %+v should be legal but was %+v", move, err)
+		}
+	}
+
+	return board
+}
+
+// Tests if the immediate recapture of a ko is rejected
+func TestSimpleKo(t *testing.T) {
+	board := newKoBoard(t, KO_SIMPLE)
+
+	// Black takes the ko
+	if err := board.Play(2, 1, BLACK); err != nil {
+		t.Fatalf("Taking the ko should be legal but was %+v", err)
+	}
+
+	if err := board.Play(1, 1, WHITE); err != ErrKo {
+		t.Errorf("Immediate recapture should be %+v but was %+v", ErrKo, err)
+	}
+
+	if board.getStatus(1, 1) != EMPTY || board.getStatus(2, 1) != BLACK {
+		t.Errorf("Rejected recapture should not change the board:\n%s", board.ToString())
+	}
+
+	// After a ko threat the recapture is legal again
+	board.Play(5, 5, WHITE)
+	board.Play(5, 6, BLACK)
+	if err := board.Play(1, 1, WHITE); err != nil {
+		t.Errorf("Recapture after a ko threat should be legal but was %+v", err)
+	}
+}
+
+// Tests the difference between positional and situational superko
+func TestSuperko(t *testing.T) {
+	results := map[KoRule]error{
+		KO_SIMPLE:              nil,
+		KO_POSITIONAL_SUPERKO:  ErrPositionalSuperko,
+		KO_SITUATIONAL_SUPERKO: nil,
+	}
+
+	for rule, expected := range results {
+		board := newKoBoard(t, rule)
+
+		// Black takes the ko, both pass and white retakes which repeats the position after black's last move
+		board.Play(2, 1, BLACK)
+		board.UndostackPushPass()
+		board.UndostackPushPass()
+
+		if err := board.Play(1, 1, WHITE); err != expected {
+			t.Errorf("Retaking with rule %d should be %+v but was %+v", rule, expected, err)
+		}
+	}
+
+	// Without black's last move white would repeat its own situation
+	board := newKoBoard(t, KO_SITUATIONAL_SUPERKO)
+	board.Undo(1)
+	board.Play(2, 1, BLACK)
+	board.UndostackPushPass()
+	board.UndostackPushPass()
+
+	if err := board.Play(1, 1, WHITE); err != ErrSituationalSuperko {
+		t.Errorf("Repeating a situation should be %+v but was %+v", ErrSituationalSuperko, err)
+	}
+}
+
+// Tests if undo removes positions from the history
+func TestUndoRemovesHistory(t *testing.T) {
+	board := newKoBoard(t, KO_POSITIONAL_SUPERKO)
+
+	board.Play(4, 4, WHITE)
+	board.Undo(1)
+
+	if err := board.Play(4, 4, WHITE); err != nil {
+		t.Errorf("Undone positions should not count for superko but was %+v", err)
+	}
+}
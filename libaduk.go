@@ -38,4 +38,12 @@ type Move struct {
 	Y        uint8
 	Color    BoardStatus
 	Captures []Position
+	Setup    bool        // Move is a setup position (AB/AW/AE), Color is the new status
+	Replaced BoardStatus // Status of a setup position before the setup
+	Suicide  bool        // Move was a suicide, Captures are the own removed stones
+}
+
+// Checks if the move is a pass
+func (move *Move) IsPass() bool {
+	return move.Color == PASS || (move.X == 255 && move.Y == 255)
 }
@@ -1,21 +1,169 @@
 package libaduk
 
-import ()
+import (
+	"bytes"
+	"fmt"
+)
 
 type Node struct {
 	Previous    *Node // Parent Node
 	Next        *Node // Child Node
 	Up          *Node // Upper sibling Node
 	Down        *Node // Lower sibling Node
-	sgfData     string
+	properties  []Property
 	numChildren int
 	level       int
 }
 
+// Represents a single SGF property with its (decoded) values
+type Property struct {
+	Identifier string
+	Values     []string
+}
+
 func NewNode(prev *Node) *Node {
-	return &Node{prev, nil, nil, nil, "", 0, 0}
+	return &Node{prev, nil, nil, nil, nil, 0, 0}
 }
 
+// Returns the SGF representation of the node without its children (e.g. ";B[aa]C[A comment]")
 func (node *Node) ToString() string {
-	return "TODO"
+	var buffer bytes.Buffer
+	writeNode(&buffer, node)
+
+	return buffer.String()
+}
+
+// Returns the SGF game tree starting at the node, including all variations
+func (node *Node) MarshalSGF() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	if err := writeCollection(&buffer, node); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// Returns a copy of the values of the property with the given identifier, use SetProperty to change them
+func (node *Node) Property(identifier string) ([]string, bool) {
+	values, ok := node.propertyValues(identifier)
+	if !ok {
+		return nil, false
+	}
+
+	return append([]string{}, values...), true
+}
+
+// Returns the values of the property without copying them, they must not be changed
+func (node *Node) propertyValues(identifier string) ([]string, bool) {
+	for _, property := range node.properties {
+		if property.Identifier == identifier {
+			return property.Values, true
+		}
+	}
+
+	return nil, false
+}
+
+// Returns the first value of the given property or an empty string if it doesn't exist
+func (node *Node) PropertyValue(identifier string) string {
+	values, ok := node.propertyValues(identifier)
+	if !ok || len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// Checks if the node has a property with the given identifier
+func (node *Node) HasProperty(identifier string) bool {
+	_, ok := node.propertyValues(identifier)
+	return ok
+}
+
+// Returns all properties of the node in the order they were added
+func (node *Node) Properties() []Property {
+	properties := make([]Property, len(node.properties))
+	for i, property := range node.properties {
+		properties[i] = Property{property.Identifier, append([]string{}, property.Values...)}
+	}
+
+	return properties
+}
+
+// Sets the values of the given property, replacing existing values. The values are copied
+func (node *Node) SetProperty(identifier string, values ...string) {
+	values = append([]string{}, values...)
+
+	for i, property := range node.properties {
+		if property.Identifier == identifier {
+			node.properties[i].Values = values
+			return
+		}
+	}
+
+	node.properties = append(node.properties, Property{identifier, values})
+}
+
+// Adds values to the given property, creating it if necessary
+func (node *Node) AddPropertyValue(identifier string, values ...string) {
+	for i, property := range node.properties {
+		if property.Identifier == identifier {
+			node.properties[i].Values = append(node.properties[i].Values, values...)
+			return
+		}
+	}
+
+	node.properties = append(node.properties, Property{identifier, append([]string{}, values...)})
+}
+
+// Removes the property with the given identifier from the node
+func (node *Node) RemoveProperty(identifier string) {
+	for i, property := range node.properties {
+		if property.Identifier == identifier {
+			node.properties = append(node.properties[:i], node.properties[i+1:]...)
+			return
+		}
+	}
+}
+
+// Returns the board size given by the SZ property of the node, 19 if there is none
+func (node *Node) BoardSize() (uint8, error) {
+	if !node.HasProperty("SZ") {
+		return 19, nil
+	}
+
+	return parseBoardSize(node.PropertyValue("SZ"))
+}
+
+// Returns the B or W move of the node or nil if the node has no move
+func (node *Node) Move(boardSize uint8) (*Move, error) {
+	color := BLACK
+	values, ok := node.propertyValues("B")
+
+	if !ok {
+		color = WHITE
+		if values, ok = node.propertyValues("W"); !ok {
+			return nil, nil
+		}
+	}
+
+	if len(values) != 1 {
+		return nil, fmt.Errorf("Invalid move %+v!", values)
+	}
+
+	if isPassValue(values[0], boardSize) {
+		return &Move{X: 255, Y: 255, Color: color}, nil
+	}
+
+	position, err := parsePoint(values[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if position.X >= boardSize || position.Y >= boardSize {
+		return nil, fmt.Errorf("Move %q is not on the board!", values[0])
+	}
+
+	return &Move{X: position.X, Y: position.Y, Color: color}, nil
 }
@@ -0,0 +1,148 @@
+package libaduk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Address of a node in a collection. The node is found by following the child indices from the root of the game,
+// or by following the main line of the game up to the given move number
+type Path struct {
+	Game       int
+	Children   []int // Index of the child at every step, 0 is the main line
+	MoveNumber int   // If set, the node of this move on the main line is addressed and Children is ignored
+}
+
+// Returns the path as string, e.g. "0:0.0.2.1" for child indices, "0:m57" for main line move 57 or "1" for the root of game 1
+func (path Path) String() string {
+	if path.MoveNumber > 0 {
+		return fmt.Sprintf("%d:m%d", path.Game, path.MoveNumber)
+	}
+
+	if len(path.Children) == 0 {
+		return strconv.Itoa(path.Game)
+	}
+
+	indices := make([]string, len(path.Children))
+	for i, child := range path.Children {
+		indices[i] = strconv.Itoa(child)
+	}
+
+	return strconv.Itoa(path.Game) + ":" + strings.Join(indices, ".")
+}
+
+// Parses a path written by Path.String
+func ParsePath(value string) (Path, error) {
+	path := Path{Children: []int{}}
+	game, rest := value, ""
+	if index := strings.IndexByte(value, ':'); index != -1 {
+		game, rest = value[:index], value[index+1:]
+	}
+
+	var err error
+	if path.Game, err = strconv.Atoi(game); err != nil || path.Game < 0 {
+		return Path{}, fmt.Errorf("Invalid path %q!", value)
+	}
+
+	if strings.HasPrefix(rest, "m") {
+		if path.MoveNumber, err = strconv.Atoi(rest[1:]); err != nil || path.MoveNumber < 1 {
+			return Path{}, fmt.Errorf("Invalid path %q!", value)
+		}
+		return path, nil
+	}
+
+	if rest == "" {
+		return path, nil
+	}
+
+	for _, index := range strings.Split(rest, ".") {
+		child, err := strconv.Atoi(index)
+		if err != nil || child < 0 {
+			return Path{}, fmt.Errorf("Invalid path %q!", value)
+		}
+		path.Children = append(path.Children, child)
+	}
+
+	return path, nil
+}
+
+// Returns the path of the current node
+func (cursor *Cursor) Path() Path {
+	nodes := pathToNode(cursor.currentNode)
+	path := Path{Children: []int{}}
+
+	for root := cursor.rootNode; root != nil && root != nodes[0]; root = root.Down {
+		path.Game++
+	}
+
+	for _, node := range nodes[1:] {
+		index := 0
+		for sibling := node.Up; sibling != nil; sibling = sibling.Up {
+			index++
+		}
+		path.Children = append(path.Children, index)
+	}
+
+	return path
+}
+
+// Moves the cursor to the node of the path. The cursor doesn't move if the node doesn't exist
+func (cursor *Cursor) GoTo(path Path) (*Node, error) {
+	node := cursor.rootNode
+	for i := 0; i < path.Game && node != nil; i++ {
+		node = node.Down
+	}
+
+	if path.Game < 0 || node == nil {
+		return nil, fmt.Errorf("Can't find %d'th Game!", path.Game)
+	}
+
+	if path.MoveNumber > 0 {
+		boardSize, err := node.BoardSize()
+		if err != nil {
+			return nil, err
+		}
+
+		number := 0
+		for ; node != nil; node = node.Next {
+			if move, _ := node.Move(boardSize); move != nil {
+				number++
+			}
+			if number == path.MoveNumber {
+				return cursor.moveTo(node)
+			}
+		}
+
+		return nil, fmt.Errorf("Main line has no move %d!", path.MoveNumber)
+	}
+
+	for step, index := range path.Children {
+		child := node.Next
+		for i := 0; i < index && child != nil; i++ {
+			child = child.Down
+		}
+
+		if index < 0 || child == nil {
+			return nil, fmt.Errorf("Can't find child %d at step %d!", index, step)
+		}
+		node = child
+	}
+
+	return cursor.moveTo(node)
+}
+
+// Returns the number of moves from the root to the current node
+func (cursor *Cursor) MoveNumber() int {
+	nodes := pathToNode(cursor.currentNode)
+	boardSize, _ := nodes[0].BoardSize()
+	number := 0
+
+	for _, node := range nodes {
+		if move, _ := node.Move(boardSize); move != nil {
+			number++
+		}
+	}
+
+	return number
+}
@@ -0,0 +1,88 @@
+package libaduk
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+// Tests parsing and writing paths
+func TestParsePath(t *testing.T) {
+	for _, value := range []string{"0", "0:0.1.1", "2:m57", "1:3"} {
+		path, err := ParsePath(value)
+		if err != nil || path.String() != value {
+			t.Errorf("Path %s should be parsed but was %s (%+v)", value, path, err)
+		}
+	}
+
+	for _, value := range []string{"", "a", "-1", "0:1..2", "0:m", "0:m0", "0:-1"} {
+		if _, err := ParsePath(value); err == nil {
+			t.Errorf("Path %q should be invalid!", value)
+		}
+	}
+}
+
+// Tests if the path of the current node leads back to it
+func TestCursorPathAndGoTo(t *testing.T) {
+	cursor, _ := NewBoardCursor([]byte(editTestGame))
+	cursor.Next(0)
+	cursor.Next(1)
+	ff, _ := cursor.Next(1)
+
+	path := cursor.Path()
+	if path.String() != "0:0.1.1" || cursor.MoveNumber() != 3 {
+		t.Errorf("Path should be 0:0.1.1 but was %s", path)
+	}
+
+	cursor.GoTo(Path{})
+	if cursor.Current() != ff.Previous.Previous.Previous || cursor.Board().getStatus(0, 0) != EMPTY {
+		t.Errorf("Cursor should be at the root!")
+	}
+
+	if node, err := cursor.GoTo(path); err != nil || node != ff || cursor.Board().getStatus(5, 5) != BLACK {
+		t.Errorf("Cursor should be at B[ff] but was %+v", err)
+	}
+
+	node, err := cursor.GoTo(Path{MoveNumber: 3})
+	if err != nil || node.PropertyValue("B") != "cc" || cursor.Board().getStatus(5, 5) != EMPTY {
+		t.Errorf("Main line move 3 should be B[cc] but was %+v", err)
+	}
+
+	for _, invalid := range []Path{{Game: 1}, {Children: []int{0, 3}}, {MoveNumber: 4}} {
+		if _, err := cursor.GoTo(invalid); err == nil || cursor.Current() != node {
+			t.Errorf("Path %s should not be found!", invalid)
+		}
+	}
+}
+
+// Tests paths in collections and deep trees
+func TestCursorPathCollection(t *testing.T) {
+	cursor, _ := NewBoardCursor([]byte("(;SZ[9];B[aa])(;SZ[9];B[bb];W[cc])"))
+
+	node, err := cursor.GoTo(Path{Game: 1, MoveNumber: 2})
+	if err != nil || node.PropertyValue("W") != "cc" || cursor.Path().String() != "1:0.0" {
+		t.Errorf("Second game should be found but was %+v", err)
+	}
+
+	sgfData, _ := ioutil.ReadFile(TestgameKogo)
+	cursor, _ = NewCursor(sgfData)
+
+	checked := 0
+	for it := cursor.Current().PreOrder(); it.Next(); {
+		if it.Depth() != 30 {
+			continue
+		}
+		checked++
+
+		cursor.moveTo(it.Node())
+		path := cursor.Path()
+		cursor.GoTo(Path{})
+
+		if node, err := cursor.GoTo(path); err != nil || node != it.Node() {
+			t.Errorf("Path %s should lead back to the node but was %+v", path, err)
+		}
+	}
+
+	if checked == 0 {
+		t.Errorf("Dictionary should have nodes at depth 30!")
+	}
+}
@@ -0,0 +1,302 @@
+package libaduk
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Content a pattern cell requires
+type PatternCell uint8
+
+const (
+	PATTERN_EMPTY PatternCell = iota // '.' empty position
+	PATTERN_BLACK                    // 'X' black stone
+	PATTERN_WHITE                    // 'O' white stone
+	PATTERN_ANY                      // '?' anything on the board
+	PATTERN_EDGE                     // '#' off the board
+)
+
+// A partial board pattern that can be matched in all symmetries
+type Pattern struct {
+	Width    uint8
+	Height   uint8
+	cells    []PatternCell // Row by row
+	variants []patternVariant
+}
+
+// The pattern transformed by a symmetry
+type patternVariant struct {
+	symmetry Symmetry
+	width    int
+	height   int
+	cells    []PatternCell
+	checks   []patternCheck // Cells that are not PATTERN_ANY, stones first
+	inner    [4]int         // Bounding box of all cells that are no edge cells (min x, min y, max x, max y)
+}
+
+// A cell of a variant that has to be checked
+type patternCheck struct {
+	x    int
+	y    int
+	cell PatternCell
+}
+
+// A match of a pattern on the board
+type PatternMatch struct {
+	Anchor   Position // Upper left position of the matched part on the board
+	Width    uint8    // Size of the matched part on the board
+	Height   uint8
+	Symmetry Symmetry // Symmetry the pattern was transformed with
+	originX  int      // Board position of the upper left cell of the transformed pattern
+	originY  int
+}
+
+// Checks if the position is part of the matched area
+func (match PatternMatch) Contains(position Position) bool {
+	return position.X >= match.Anchor.X && position.X < match.Anchor.X+match.Width &&
+		position.Y >= match.Anchor.Y && position.Y < match.Anchor.Y+match.Height
+}
+
+// Parses a pattern from rows of cells ('X' black, 'O' white, '.' empty, '?' any, '#' edge).
+// Spaces are ignored and edge cells are only allowed in the outer rows and columns
+func ParsePattern(rows ...string) (*Pattern, error) {
+	pattern := &Pattern{cells: []PatternCell{}}
+
+	for y, row := range rows {
+		row = strings.Replace(row, " ", "", -1)
+
+		if y == 0 {
+			pattern.Width = uint8(len(row))
+		} else if len(row) != int(pattern.Width) {
+			return nil, fmt.Errorf("All pattern rows need the same width!")
+		}
+
+		for _, c := range row {
+			switch c {
+			case '.':
+				pattern.cells = append(pattern.cells, PATTERN_EMPTY)
+			case 'X':
+				pattern.cells = append(pattern.cells, PATTERN_BLACK)
+			case 'O':
+				pattern.cells = append(pattern.cells, PATTERN_WHITE)
+			case '?':
+				pattern.cells = append(pattern.cells, PATTERN_ANY)
+			case '#':
+				pattern.cells = append(pattern.cells, PATTERN_EDGE)
+			default:
+				return nil, fmt.Errorf("Invalid pattern cell %q!", c)
+			}
+		}
+	}
+
+	pattern.Height = uint8(len(rows))
+	if pattern.Width == 0 || pattern.Height == 0 {
+		return nil, fmt.Errorf("Pattern can not be empty!")
+	}
+
+	onlyEdges := true
+	for _, cell := range pattern.cells {
+		onlyEdges = onlyEdges && cell == PATTERN_EDGE
+	}
+	if onlyEdges {
+		return nil, fmt.Errorf("Pattern needs at least one cell on the board!")
+	}
+
+	for y := 0; y < int(pattern.Height); y++ {
+		for x := 0; x < int(pattern.Width); x++ {
+			inner := x > 0 && y > 0 && x < int(pattern.Width)-1 && y < int(pattern.Height)-1
+			if inner && pattern.Cell(uint8(x), uint8(y)) == PATTERN_EDGE {
+				return nil, fmt.Errorf("Edge cells are only allowed at the border of the pattern!")
+			}
+		}
+	}
+
+	pattern.createVariants()
+
+	return pattern, nil
+}
+
+// Returns the cell at the given pattern coordinates
+func (pattern *Pattern) Cell(x uint8, y uint8) PatternCell {
+	return pattern.cells[int(y)*int(pattern.Width)+int(x)]
+}
+
+// Creates the transformed patterns for all symmetries, symmetries that give the same pattern are skipped
+func (pattern *Pattern) createVariants() {
+	pattern.variants = []patternVariant{}
+
+	for _, sym := range Symmetries {
+		variant := patternVariant{symmetry: sym, width: int(pattern.Width), height: int(pattern.Height)}
+		if sym%2 == 1 {
+			variant.width, variant.height = variant.height, variant.width
+		}
+
+		variant.cells = make([]PatternCell, len(pattern.cells))
+		for i, cell := range pattern.cells {
+			x, y := pattern.transformCell(i%int(pattern.Width), i/int(pattern.Width), sym)
+			variant.cells[y*variant.width+x] = cell
+		}
+
+		if pattern.hasVariant(variant) {
+			continue
+		}
+
+		variant.inner = [4]int{variant.width, variant.height, -1, -1}
+		for i, cell := range variant.cells {
+			if cell != PATTERN_EDGE {
+				x, y := i%variant.width, i/variant.width
+				variant.inner = [4]int{minInt(variant.inner[0], x), minInt(variant.inner[1], y), maxInt(variant.inner[2], x), maxInt(variant.inner[3], y)}
+			}
+		}
+
+		// Stones are checked first because they fail fastest
+		for _, wanted := range []PatternCell{PATTERN_BLACK, PATTERN_WHITE, PATTERN_EMPTY, PATTERN_EDGE} {
+			for i, cell := range variant.cells {
+				if cell == wanted {
+					variant.checks = append(variant.checks, patternCheck{i % variant.width, i / variant.width, cell})
+				}
+			}
+		}
+
+		pattern.variants = append(pattern.variants, variant)
+	}
+}
+
+// Checks if a variant with the same cells already exists
+func (pattern *Pattern) hasVariant(variant patternVariant) bool {
+	for _, existing := range pattern.variants {
+		if existing.width != variant.width {
+			continue
+		}
+
+		same := true
+		for i := range existing.cells {
+			if existing.cells[i] != variant.cells[i] {
+				same = false
+				break
+			}
+		}
+
+		if same {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Transforms pattern coordinates by sym like Position.Transform does for the board
+func (pattern *Pattern) transformCell(x int, y int, sym Symmetry) (int, int) {
+	width, height := int(pattern.Width), int(pattern.Height)
+
+	if sym >= MIRROR {
+		x = width - 1 - x
+	}
+
+	// Rotate clockwise
+	for i := Symmetry(0); i < sym%4; i++ {
+		x, y = height-1-y, x
+		width, height = height, width
+	}
+
+	return x, y
+}
+
+// Returns the original pattern coordinates of a board position that is part of the match
+func (pattern *Pattern) PatternPosition(match PatternMatch, position Position) (Position, bool) {
+	if !match.Contains(position) {
+		return Position{}, false
+	}
+
+	x, y := int(position.X)-match.originX, int(position.Y)-match.originY
+
+	// Find the pattern cell that is transformed to (x, y)
+	for i := range pattern.cells {
+		cellX, cellY := i%int(pattern.Width), i/int(pattern.Width)
+		if tx, ty := pattern.transformCell(cellX, cellY, match.Symmetry); tx == x && ty == y {
+			return Position{uint8(cellX), uint8(cellY)}, true
+		}
+	}
+
+	return Position{}, false
+}
+
+// Returns all matches of the pattern on the board
+func (board *AbstractBoard) Match(pattern *Pattern) []PatternMatch {
+	matches := []PatternMatch{}
+	size := int(board.BoardSize)
+
+	// All cells but the edge cells have to be on the board
+	for _, variant := range pattern.variants {
+		for originX := -variant.inner[0]; originX+variant.inner[2] < size; originX++ {
+			for originY := -variant.inner[1]; originY+variant.inner[3] < size; originY++ {
+				if board.matchVariant(&variant, originX, originY) {
+					matches = append(matches, newPatternMatch(&variant, originX, originY))
+				}
+			}
+		}
+	}
+
+	return matches
+}
+
+// Checks if the variant matches with its upper left cell at the given board position
+func (board *AbstractBoard) matchVariant(variant *patternVariant, originX int, originY int) bool {
+	size := int(board.BoardSize)
+
+	for _, check := range variant.checks {
+		x, y := originX+check.x, originY+check.y
+
+		if check.cell == PATTERN_EDGE {
+			if x >= 0 && y >= 0 && x < size && y < size {
+				return false
+			}
+			continue
+		}
+
+		switch board.data[x*size+y] {
+		case EMPTY:
+			if check.cell != PATTERN_EMPTY {
+				return false
+			}
+		case BLACK:
+			if check.cell != PATTERN_BLACK {
+				return false
+			}
+		case WHITE:
+			if check.cell != PATTERN_WHITE {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Creates the match for the part of the variant that is on the board
+func newPatternMatch(variant *patternVariant, originX int, originY int) PatternMatch {
+	return PatternMatch{
+		Anchor:   Position{uint8(originX + variant.inner[0]), uint8(originY + variant.inner[1])},
+		Width:    uint8(variant.inner[2] - variant.inner[0] + 1),
+		Height:   uint8(variant.inner[3] - variant.inner[1] + 1),
+		Symmetry: variant.symmetry,
+		originX:  originX,
+		originY:  originY,
+	}
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,88 @@
+package libaduk
+
+import (
+	"testing"
+)
+
+// Tests parsing of patterns
+func TestParsePattern(t *testing.T) {
+	pattern, err := ParsePattern(
+		"# # # #",
+		"# . X ?",
+		"# O . .",
+	)
+
+	if err != nil {
+		t.Fatalf("Pattern should be parsed but was %+v", err)
+	}
+
+	if pattern.Width != 4 || pattern.Height != 3 || pattern.Cell(2, 1) != PATTERN_BLACK || pattern.Cell(3, 1) != PATTERN_ANY {
+		t.Errorf("Unexpected pattern %+v", pattern)
+	}
+
+	invalid := [][]string{{"..", "..."}, {"...", ".#.", "..."}, {"ab"}, {"##"}}
+	for _, rows := range invalid {
+		if _, err := ParsePattern(rows...); err == nil {
+			t.Errorf("Pattern %+v should be invalid!", rows)
+		}
+	}
+}
+
+// Tests matching a corner pattern in all orientations
+func TestMatchCornerPattern(t *testing.T) {
+	pattern, _ := ParsePattern(
+		"####",
+		"#...",
+		"#.X.",
+		"#...",
+	)
+
+	board, _ := NewBoard(9)
+	board.Play(1, 1, BLACK)
+	board.Play(7, 7, BLACK)
+	board.Play(4, 4, BLACK)
+
+	matches := board.Match(pattern)
+	if len(matches) != 2 {
+		t.Fatalf("Pattern should match in 2 corners but was %+v", matches)
+	}
+
+	for _, match := range matches {
+		if !match.Contains(Position{1, 1}) && !match.Contains(Position{7, 7}) {
+			t.Errorf("Match %+v should contain one of the corner stones", match)
+		}
+
+		if match.Width != 3 || match.Height != 3 {
+			t.Errorf("Matched area should be 3x3 but was %+v", match)
+		}
+	}
+
+	// The lower right corner is matched by a rotated pattern, (8, 8) is the upper left empty position of the pattern
+	for _, match := range matches {
+		if match.Contains(Position{7, 7}) {
+			if position, ok := pattern.PatternPosition(match, Position{8, 8}); !ok || position != (Position{1, 1}) {
+				t.Errorf("Pattern position of (8, 8) should be (1, 1) but was %+v", position)
+			}
+		}
+	}
+}
+
+// Tests wildcards and patterns without edges
+func TestMatchWildcardPattern(t *testing.T) {
+	pattern, _ := ParsePattern(
+		"X?O",
+	)
+
+	board, _ := NewBoard(5)
+	board.Play(0, 0, BLACK)
+	board.Play(2, 0, WHITE)
+	board.Play(2, 2, BLACK)
+	board.Play(2, 3, BLACK)
+	board.Play(2, 4, WHITE)
+
+	matches := board.Match(pattern)
+	// (2, 2) is part of a vertical match in both directions
+	if len(matches) != 3 {
+		t.Errorf("Pattern should match 3 times but was %+v", matches)
+	}
+}
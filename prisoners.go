@@ -0,0 +1,94 @@
+package libaduk
+
+import (
+	"fmt"
+)
+
+// Stones captured by a single move
+type Capture struct {
+	MoveNumber int         // Number of the move, setup entries are not counted
+	Captor     BoardStatus // Color that gets the stones as prisoners
+	Stones     []Position
+}
+
+// Returns the number of prisoners color has taken
+func (board *AbstractBoard) Prisoners(color BoardStatus) int {
+	return board.prisoners[color]
+}
+
+// Set the number of prisoners color has taken, e.g. when the game starts from a setup position
+func (board *AbstractBoard) SetPrisoners(color BoardStatus, count int) error {
+	if color != BLACK && color != WHITE {
+		return fmt.Errorf("Invalid prisoner color (%d)!", color)
+	}
+
+	if count < 0 {
+		return fmt.Errorf("Prisoners can not be negative!")
+	}
+
+	board.prisoners[color] = count
+
+	return nil
+}
+
+// Returns the stones captured by move number n (starting at 1, setup entries are not counted)
+func (board *AbstractBoard) CapturesByMove(n int) ([]Position, error) {
+	number := 0
+
+	for _, move := range board.undoStack {
+		if move.Setup {
+			continue
+		}
+
+		number++
+		if number == n {
+			captures := make([]Position, len(move.Captures))
+			copy(captures, move.Captures)
+
+			return captures, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Move %d was not played!", n)
+}
+
+// Returns all captures of the moves played so far
+func (board *AbstractBoard) Captures() []Capture {
+	captures := []Capture{}
+	number := 0
+
+	for _, move := range board.undoStack {
+		if move.Setup {
+			continue
+		}
+
+		number++
+		if len(move.Captures) == 0 {
+			continue
+		}
+
+		stones := make([]Position, len(move.Captures))
+		copy(stones, move.Captures)
+		captures = append(captures, Capture{MoveNumber: number, Captor: captor(move), Stones: stones})
+	}
+
+	return captures
+}
+
+// Adds the prisoners of the move times sign to the prisoner counts
+func (board *AbstractBoard) countCaptures(move *Move, sign int) {
+	if move.Setup || len(move.Captures) == 0 {
+		return
+	}
+
+	board.prisoners[captor(move)] += sign * len(move.Captures)
+}
+
+// Returns the color that gets the captures of the move as prisoners, the opponent for a suicide
+func captor(move *Move) BoardStatus {
+	if move.Suicide {
+		return move.Color.invert()
+	}
+
+	return move.Color
+}
@@ -0,0 +1,78 @@
+package libaduk
+
+import (
+	"testing"
+)
+
+// Tests if prisoners are counted on play and undo
+func TestPrisoners(t *testing.T) {
+	board, _ := NewBoard(5)
+
+	// White captures the black stone in the corner, black captures three white stones on the edge
+	board.Play(0, 0, BLACK)
+	board.Play(1, 0, WHITE)
+	board.Play(2, 2, BLACK)
+	board.Play(0, 1, WHITE)
+	board.Play(4, 0, BLACK)
+	board.Play(3, 0, WHITE)
+	board.Play(4, 1, BLACK)
+	board.Play(2, 0, WHITE)
+	board.Play(3, 1, BLACK)
+	board.Play(4, 4, WHITE)
+	board.Play(2, 1, BLACK)
+	board.Play(4, 3, WHITE)
+	board.Play(1, 1, BLACK)
+	board.Play(3, 3, WHITE)
+	board.Play(0, 0, BLACK)
+
+	if board.Prisoners(WHITE) != 1 || board.Prisoners(BLACK) != 3 {
+		t.Errorf("Prisoners should be 3 for black and 1 for white but were %d and %d", board.Prisoners(BLACK), board.Prisoners(WHITE))
+	}
+
+	captures := board.Captures()
+	if len(captures) != 2 || captures[0].MoveNumber != 4 || captures[0].Captor != WHITE || captures[1].MoveNumber != 15 || len(captures[1].Stones) != 3 {
+		t.Errorf("Unexpected captures %+v", captures)
+	}
+
+	if stones, err := board.CapturesByMove(4); err != nil || len(stones) != 1 || stones[0] != (Position{0, 0}) {
+		t.Errorf("Move 4 should capture (0, 0) but was %+v (%+v)", stones, err)
+	}
+
+	if _, err := board.CapturesByMove(16); err == nil {
+		t.Errorf("Move 16 was not played!")
+	}
+
+	board.Undo(1)
+	if board.Prisoners(BLACK) != 0 || board.Prisoners(WHITE) != 1 {
+		t.Errorf("Undo should remove the prisoners of the last move but was %d", board.Prisoners(BLACK))
+	}
+}
+
+// Tests prisoners of a suicide and seeded prisoners
+func TestPrisonersSuicideAndSeed(t *testing.T) {
+	board, _ := NewBoardWithRules(3, NewZealandRules)
+	board.SetPrisoners(BLACK, 5)
+
+	board.Play(1, 0, WHITE)
+	board.Play(0, 1, WHITE)
+	board.Play(0, 0, BLACK)
+
+	if board.Prisoners(WHITE) != 1 || board.Prisoners(BLACK) != 5 {
+		t.Errorf("Suicide should give white a prisoner but was %d", board.Prisoners(WHITE))
+	}
+
+	result, _ := board.Score(nil, 0)
+	if board.rules.Scoring != AREA_SCORING || result.Black != 0 {
+		t.Errorf("Prisoners should not count with area scoring but was %+v", result)
+	}
+
+	board.rules = JapaneseRules
+	result, _ = board.Score(nil, 0)
+	if result.Black != 5 || result.White != 1+7 {
+		t.Errorf("Prisoners should count with territory scoring but was %+v", result)
+	}
+
+	if err := board.SetPrisoners(EMPTY, 1); err == nil {
+		t.Errorf("Prisoners of EMPTY should not be set!")
+	}
+}
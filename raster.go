@@ -0,0 +1,350 @@
+package libaduk
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+	"strconv"
+)
+
+// Colors of raster images, the board color comes first so it is the background of new images
+var rasterPalette = color.Palette{
+	color.RGBA{0xdc, 0xb3, 0x5c, 0xff},
+	color.Black,
+	color.White,
+}
+
+const (
+	rasterBoard uint8 = iota
+	rasterBlack
+	rasterWhite
+)
+
+// Draws the current board position as PNG
+func (board *AbstractBoard) RenderPNG(w io.Writer, options RenderOptions) error {
+	img, err := renderImage(boardDiagram(board), options)
+	if err != nil {
+		return err
+	}
+
+	return png.Encode(w, img)
+}
+
+// Draws the board at the current node with the markup of the node as PNG, labels use a small built-in font
+func (cursor *Cursor) RenderPNG(w io.Writer, options RenderOptions) error {
+	d, err := cursorDiagram(cursor)
+	if err != nil {
+		return err
+	}
+
+	img, err := renderImage(d, options)
+	if err != nil {
+		return err
+	}
+
+	return png.Encode(w, img)
+}
+
+// Writes an animated GIF with a frame for every node from the game root to the current node.
+// Delay is the time every frame is shown in 100ths of a second
+func (cursor *Cursor) RenderGIF(w io.Writer, delay int, options RenderOptions) error {
+	return cursor.renderGIF(w, pathToNode(cursor.currentNode), delay, options)
+}
+
+// Writes an animated GIF of the main line of the current game, see RenderGIF
+func (cursor *Cursor) RenderMainLineGIF(w io.Writer, delay int, options RenderOptions) error {
+	path := pathToNode(cursor.currentNode)[:1]
+	for node := path[0].Next; node != nil; node = node.Next {
+		path = append(path, node)
+	}
+
+	return cursor.renderGIF(w, path, delay, options)
+}
+
+// Moves the cursor along the path and adds a frame for every node, the cursor is moved back afterwards
+func (cursor *Cursor) renderGIF(w io.Writer, path []*Node, delay int, options RenderOptions) error {
+	if cursor.board == nil {
+		return fmt.Errorf("Cursor has no board!")
+	}
+
+	current := cursor.currentNode
+	animation, err := cursor.renderFrames(path, delay, options)
+	_, restoreErr := cursor.moveTo(current)
+
+	// The error of the frame is the cause, a failed restore is only reported without it
+	if err != nil {
+		return err
+	}
+
+	if restoreErr != nil {
+		return restoreErr
+	}
+
+	return gif.EncodeAll(w, animation)
+}
+
+// Moves the cursor along the path and draws a frame for every node
+func (cursor *Cursor) renderFrames(path []*Node, delay int, options RenderOptions) (*gif.GIF, error) {
+	animation := &gif.GIF{}
+
+	for _, node := range path {
+		if _, err := cursor.moveTo(node); err != nil {
+			return nil, err
+		}
+
+		d, err := cursorDiagram(cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		img, err := renderImage(d, options)
+		if err != nil {
+			return nil, err
+		}
+
+		animation.Image = append(animation.Image, img)
+		animation.Delay = append(animation.Delay, delay)
+	}
+
+	return animation, nil
+}
+
+// Draws the diagram on a paletted image, text is drawn with the built-in font
+func renderImage(d *diagram, options RenderOptions) (*image.Paletted, error) {
+	if options.Coordinates && d.size > maxVertexBoardSize {
+		return nil, fmt.Errorf("Coordinates are only supported up to %dx%d!", maxVertexBoardSize, maxVertexBoardSize)
+	}
+
+	if options.Region != nil {
+		if err := d.crop(*options.Region); err != nil {
+			return nil, err
+		}
+	}
+
+	layout := newDiagramLayout(d, options)
+	cell := layout.cell
+	img := image.NewPaletted(image.Rect(0, 0, layout.width, layout.height), rasterPalette)
+
+	// Lines continue half a position where the region doesn't end at the border of the board
+	left, top := layout.point(d, d.region.From)
+	right, bottom := layout.point(d, d.region.To)
+	if d.region.From.X > 0 {
+		left -= cell / 2
+	}
+	if d.region.From.Y > 0 {
+		top -= cell / 2
+	}
+	if d.region.To.X < d.size-1 {
+		right += cell / 2
+	}
+	if d.region.To.Y < d.size-1 {
+		bottom += cell / 2
+	}
+
+	for x := d.region.From.X; x <= d.region.To.X; x++ {
+		px, _ := layout.point(d, Position{x, 0})
+		drawLine(img, px, top, px, bottom, rasterBlack)
+	}
+	for y := d.region.From.Y; y <= d.region.To.Y; y++ {
+		_, py := layout.point(d, Position{0, y})
+		drawLine(img, left, py, right, py, rasterBlack)
+	}
+
+	for _, star := range d.starPoints() {
+		if d.contains(star) {
+			px, py := layout.point(d, star)
+			fillCircle(img, px, py, maxInt(cell/10, 1), rasterBlack)
+		}
+	}
+
+	for x := d.region.From.X; x <= d.region.To.X; x++ {
+		for y := d.region.From.Y; y <= d.region.To.Y; y++ {
+			position := Position{x, y}
+			px, py := layout.point(d, position)
+			stone := d.stoneAt(position)
+
+			switch stone {
+			case BLACK:
+				fillCircle(img, px, py, cell*12/25, rasterBlack)
+			case WHITE:
+				fillCircle(img, px, py, cell*12/25, rasterBlack)
+				fillCircle(img, px, py, cell*12/25-1, rasterWhite)
+			}
+
+			drawRasterMarkup(img, d, position, px, py, cell, options.LastMove)
+		}
+	}
+
+	for _, line := range d.lines {
+		if !d.contains(line.from) || !d.contains(line.to) {
+			continue
+		}
+
+		x1, y1 := layout.point(d, line.from)
+		x2, y2 := layout.point(d, line.to)
+		drawLine(img, x1, y1, x2, y2, rasterBlack)
+
+		if line.arrow {
+			fillCircle(img, x2, y2, maxInt(cell/8, 1), rasterBlack)
+		}
+	}
+
+	if options.Coordinates {
+		fontSize := cell * 2 / 5
+		for x := d.region.From.X; x <= d.region.To.X; x++ {
+			px, _ := layout.point(d, Position{x, 0})
+			drawText(img, string(columnLetter(x)), px, cell/2, fontSize, rasterBlack)
+		}
+		for y := d.region.From.Y; y <= d.region.To.Y; y++ {
+			_, py := layout.point(d, Position{0, y})
+			drawText(img, strconv.Itoa(int(d.size)-int(y)), cell/2, py, fontSize, rasterBlack)
+		}
+	}
+
+	return img, nil
+}
+
+// Draws the label, mark or last move marker of the position
+func drawRasterMarkup(img *image.Paletted, d *diagram, position Position, px int, py int, cell int, lastMove bool) {
+	stone := d.stoneAt(position)
+	markColor := rasterBlack
+	if stone == BLACK {
+		markColor = rasterWhite
+	}
+
+	if label, ok := d.labels[position]; ok {
+		// Labels on empty positions hide the lines
+		if stone == EMPTY {
+			fillCircle(img, px, py, cell*2/5, rasterBoard)
+		}
+
+		fontSize := cell / 2
+		if len(label) > 2 {
+			fontSize = cell * 2 / 5
+		}
+		drawText(img, label, px, py, fontSize, markColor)
+		return
+	}
+
+	r := cell / 4
+	switch d.marks[position] {
+	case TRIANGLE:
+		drawLine(img, px, py-r, px-r, py+r*3/4, markColor)
+		drawLine(img, px-r, py+r*3/4, px+r, py+r*3/4, markColor)
+		drawLine(img, px+r, py+r*3/4, px, py-r, markColor)
+	case CIRCLE:
+		fillCircle(img, px, py, r, markColor)
+		fillCircle(img, px, py, r-2, paletteIndex(stone))
+	case SQUARE:
+		drawLine(img, px-r, py-r, px+r, py-r, markColor)
+		drawLine(img, px+r, py-r, px+r, py+r, markColor)
+		drawLine(img, px+r, py+r, px-r, py+r, markColor)
+		drawLine(img, px-r, py+r, px-r, py-r, markColor)
+	case CROSS:
+		drawLine(img, px-r, py-r, px+r, py+r, markColor)
+		drawLine(img, px+r, py-r, px-r, py+r, markColor)
+	default:
+		if lastMove && d.lastMove != nil && *d.lastMove == position {
+			fillCircle(img, px, py, maxInt(cell/6, 1), markColor)
+		}
+	}
+}
+
+// Returns the palette index of the board or stone color
+func paletteIndex(stone BoardStatus) uint8 {
+	switch stone {
+	case BLACK:
+		return rasterBlack
+	case WHITE:
+		return rasterWhite
+	}
+
+	return rasterBoard
+}
+
+// Fills a circle with the given center and radius
+func fillCircle(img *image.Paletted, cx int, cy int, r int, index uint8) {
+	for y := -r; y <= r; y++ {
+		for x := -r; x <= r; x++ {
+			if x*x+y*y <= r*r {
+				img.SetColorIndex(cx+x, cy+y, index)
+			}
+		}
+	}
+}
+
+// Draws a line from (x1, y1) to (x2, y2) with the Bresenham algorithm
+func drawLine(img *image.Paletted, x1 int, y1 int, x2 int, y2 int, index uint8) {
+	dx, dy := x2-x1, y2-y1
+	stepX, stepY := 1, 1
+	if dx < 0 {
+		dx, stepX = -dx, -1
+	}
+	if dy < 0 {
+		dy, stepY = -dy, -1
+	}
+
+	err := dx - dy
+	for {
+		img.SetColorIndex(x1, y1, index)
+		if x1 == x2 && y1 == y2 {
+			return
+		}
+
+		double := 2 * err
+		if double > -dy {
+			err -= dy
+			x1 += stepX
+		}
+		if double < dx {
+			err += dx
+			y1 += stepY
+		}
+	}
+}
+
+// Glyphs of the built-in font, 3x5 pixels row by row. Lowercase letters use the uppercase glyphs
+var rasterGlyphs = map[byte]string{
+	'0': "111101101101111", '1': "010110010010111", '2': "111001111100111", '3': "111001111001111",
+	'4': "101101111001001", '5': "111100111001111", '6': "111100111101111", '7': "111001001010010",
+	'8': "111101111101111", '9': "111101111001111",
+	'A': "010101111101101", 'B': "110101110101110", 'C': "011100100100011", 'D': "110101101101110",
+	'E': "111100110100111", 'F': "111100110100100", 'G': "011100101101011", 'H': "101101111101101",
+	'I': "111010010010111", 'J': "001001001101010", 'K': "101101110101101", 'L': "100100100100111",
+	'M': "101111111101101", 'N': "110101101101101", 'O': "010101101101010", 'P': "110101110100100",
+	'Q': "010101101110011", 'R': "110101110101101", 'S': "011100010001110", 'T': "111010010010010",
+	'U': "101101101101111", 'V': "101101101101010", 'W': "101101111111101", 'X': "101101010101101",
+	'Y': "101101010010010", 'Z': "111001010100111",
+}
+
+// Draws the text centered at (cx, cy) with the built-in font scaled to about the given height.
+// Characters without a glyph leave a gap
+func drawText(img *image.Paletted, text string, cx int, cy int, height int, index uint8) {
+	scale := maxInt(height/5, 1)
+	left := cx - (len(text)*4-1)*scale/2
+	top := cy - 5*scale/2
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+
+		glyph := rasterGlyphs[c]
+		for pixel := 0; pixel < len(glyph); pixel++ {
+			if glyph[pixel] != '1' {
+				continue
+			}
+
+			x, y := left+(i*4+pixel%3)*scale, top+pixel/3*scale
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetColorIndex(x+dx, y+dy, index)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package libaduk
+
+import (
+	"bytes"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// Tests if stones are drawn on the PNG
+func TestBoardRenderPNG(t *testing.T) {
+	board, _ := NewBoard(9)
+	board.Play(2, 6, BLACK)
+	board.Play(6, 2, WHITE)
+
+	var buffer bytes.Buffer
+	if err := board.RenderPNG(&buffer, RenderOptions{Size: 200, LastMove: true}); err != nil {
+		t.Fatalf("Board should be rendered but was %+v", err)
+	}
+
+	img, err := png.Decode(&buffer)
+	if err != nil {
+		t.Fatalf("PNG should be decoded but was %+v", err)
+	}
+
+	// 20 pixels per position, the first position is at (20, 20)
+	if img.Bounds().Dx() != 200 || img.Bounds().Dy() != 200 {
+		t.Errorf("Image should be 200x200 but was %+v", img.Bounds())
+	}
+
+	tests := []struct {
+		x, y  int
+		color color.Color
+	}{
+		{66, 146, color.Black},     // Black stone
+		{144, 64, color.White},     // White stone
+		{140, 60, color.Black},     // Last move marker on the white stone
+		{20, 20, color.Black},      // Corner of the lines
+		{30, 30, rasterPalette[0]}, // Empty board
+		{5, 5, rasterPalette[0]},   // Border
+	}
+
+	for _, test := range tests {
+		r1, g1, b1, _ := img.At(test.x, test.y).RGBA()
+		r2, g2, b2, _ := test.color.RGBA()
+		if r1 != r2 || g1 != g2 || b1 != b2 {
+			t.Errorf("Pixel (%d, %d) should be %+v but was %+v", test.x, test.y, test.color, img.At(test.x, test.y))
+		}
+	}
+}
+
+// Tests animated GIFs of the path to the current node and the main line
+func TestCursorRenderGIF(t *testing.T) {
+	sgfData, _ := ioutil.ReadFile(TestgameSmall)
+	cursor, _ := NewBoardCursor(sgfData)
+	cursor.Next(0)
+	cursor.Next(0)
+	current := cursor.Current()
+	hash := cursor.Board().GetHash()
+
+	var buffer bytes.Buffer
+	if err := cursor.RenderGIF(&buffer, 50, RenderOptions{Size: 100, LastMove: true}); err != nil {
+		t.Fatalf("GIF should be rendered but was %+v", err)
+	}
+
+	animation, err := gif.DecodeAll(&buffer)
+	if err != nil || len(animation.Image) != 3 || animation.Delay[0] != 50 {
+		t.Errorf("GIF should have 3 frames but was %+v", err)
+	}
+
+	if cursor.Current() != current || cursor.Board().GetHash() != hash {
+		t.Errorf("Cursor should be moved back to the current node!")
+	}
+
+	mainLine := 1
+	for node := cursor.rootNode.Next; node != nil; node = node.Next {
+		mainLine++
+	}
+
+	buffer.Reset()
+	cursor.RenderMainLineGIF(&buffer, 10, RenderOptions{Size: 100, Region: &Region{Position{0, 0}, Position{4, 4}}})
+	animation, err = gif.DecodeAll(&buffer)
+	if err != nil || len(animation.Image) != mainLine {
+		t.Errorf("GIF should have %d frames but was %+v", mainLine, err)
+	}
+}
+
+// Tests if labels and coordinates are drawn with the built-in font
+func TestRenderPNGText(t *testing.T) {
+	cursor, _ := NewBoardCursor([]byte("(;SZ[9];B[cc];W[dd]LB[ee:A][cc:1])"))
+	cursor.Next(0)
+	cursor.Next(0)
+
+	var buffer bytes.Buffer
+	if err := cursor.RenderPNG(&buffer, RenderOptions{Size: 200, Coordinates: true}); err != nil {
+		t.Fatalf("Labels and coordinates should be rendered but was %+v", err)
+	}
+
+	img, err := png.Decode(&buffer)
+	if err != nil {
+		t.Fatalf("PNG should be decoded but was %+v", err)
+	}
+
+	// 18 pixels per position with a border of two positions, the font is 3x5 pixels
+	tests := []struct {
+		x, y  int
+		color color.Color
+	}{
+		{108, 106, color.Black},      // Top of the A on the empty position
+		{108, 107, rasterPalette[0]}, // Inside the A the line is hidden
+		{72, 70, color.White},        // Top of the 1 on the black stone
+		{36, 7, color.Black},         // Top of the column letter A
+		{8, 34, color.Black},         // Upper left corner of the row number 9
+		{9, 35, rasterPalette[0]},    // Inside the row number 9
+	}
+
+	for _, test := range tests {
+		r1, g1, b1, _ := img.At(test.x, test.y).RGBA()
+		r2, g2, b2, _ := test.color.RGBA()
+		if r1 != r2 || g1 != g2 || b1 != b2 {
+			t.Errorf("Pixel (%d, %d) should be %+v but was %+v", test.x, test.y, test.color, img.At(test.x, test.y))
+		}
+	}
+}
+
+// Tests that a failed frame is reported and the cursor stays at its node
+func TestRenderGIFFailedFrame(t *testing.T) {
+	cursor, _ := NewBoardCursor([]byte("(;SZ[30];B[cc];W[dd])"))
+	cursor.Next(0)
+	current := cursor.Current()
+
+	err := cursor.RenderMainLineGIF(ioutil.Discard, 10, RenderOptions{Coordinates: true})
+	if err == nil || !strings.Contains(err.Error(), "Coordinates") {
+		t.Errorf("Coordinates on 30x30 should fail but was %+v", err)
+	}
+
+	if cursor.Current() != current || cursor.Board().getStatus(3, 3) != EMPTY {
+		t.Errorf("Cursor should be moved back after a failed frame!")
+	}
+}
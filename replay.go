@@ -0,0 +1,205 @@
+package libaduk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Create a new cursor for given sgf data that keeps a board in sync with the current node
+func NewBoardCursor(sgf []byte) (*Cursor, error) {
+	cursor, err := NewCursor(sgf)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cursor.syncBoard(cursor.currentNode); err != nil {
+		return nil, err
+	}
+
+	return cursor, nil
+}
+
+// Returns the board showing the position at the current node (nil if the cursor has no board)
+func (cursor *Cursor) Board() *AbstractBoard {
+	return cursor.board
+}
+
+// Creates an empty board with the size and rules of the game root.
+// Unknown rules are no reason to refuse the replay, we use the default rules instead
+func newRootBoard(root *Node) (*AbstractBoard, error) {
+	boardSize, err := root.BoardSize()
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := RulesetFromSGF(root.PropertyValue("RU"))
+	if err != nil {
+		rules = JapaneseRules
+	}
+
+	return NewBoardWithRules(boardSize, rules)
+}
+
+// Updates the board to show the position at the given node
+func (cursor *Cursor) syncBoard(target *Node) error {
+	path := pathToNode(target)
+
+	// A different game needs a new board
+	if cursor.board == nil || len(cursor.boardPath) == 0 || cursor.boardPath[0] != path[0] {
+		board, err := newRootBoard(path[0])
+		if err != nil {
+			return err
+		}
+
+		undo, err := applyNodes(board, path)
+		if err != nil {
+			return err
+		}
+
+		cursor.board = board
+		cursor.boardPath = path
+		cursor.boardUndo = undo
+		return nil
+	}
+
+	// Take back all nodes that are not shared by both paths
+	common := 0
+	for common < len(path) && common < len(cursor.boardPath) && path[common] == cursor.boardPath[common] {
+		common++
+	}
+
+	oldPath := cursor.boardPath
+	cursor.undoBoardPath(common)
+
+	undo, err := applyNodes(cursor.board, path[common:])
+	if err != nil {
+		// Restore the old position, these nodes were already played successfully before
+		undo, _ = applyNodes(cursor.board, oldPath[common:])
+		cursor.boardPath = append(cursor.boardPath, oldPath[common:]...)
+		cursor.boardUndo = append(cursor.boardUndo, undo...)
+		return err
+	}
+
+	cursor.boardPath = append(cursor.boardPath, path[common:]...)
+	cursor.boardUndo = append(cursor.boardUndo, undo...)
+
+	return nil
+}
+
+// Takes back nodes from the board until only length nodes of the board path are left
+func (cursor *Cursor) undoBoardPath(length int) {
+	for len(cursor.boardPath) > length {
+		last := len(cursor.boardPath) - 1
+		cursor.board.Undo(cursor.boardUndo[last])
+		cursor.boardPath = cursor.boardPath[:last]
+		cursor.boardUndo = cursor.boardUndo[:last]
+	}
+}
+
+// Plays the setup and moves of all nodes and returns the number of undostack entries of each node.
+// If a node can't be played the board is restored and an error returned
+func applyNodes(board *AbstractBoard, nodes []*Node) ([]int, error) {
+	undo := make([]int, 0, len(nodes))
+
+	for _, node := range nodes {
+		count, err := applyNode(board, node)
+		if err != nil {
+			for i := len(undo) - 1; i >= 0; i-- {
+				board.Undo(undo[i])
+			}
+			return nil, err
+		}
+
+		undo = append(undo, count)
+	}
+
+	return undo, nil
+}
+
+// Plays the setup and the move of a single node and returns the number of added undostack entries
+func applyNode(board *AbstractBoard, node *Node) (int, error) {
+	stackSize := len(board.undoStack)
+
+	// Handicap stones of the root node are placed as handicap, so white moves first
+	handicap := rootHandicap(board, node)
+
+	for _, setup := range []struct {
+		identifier string
+		status     BoardStatus
+	}{{"AE", EMPTY}, {"AB", BLACK}, {"AW", WHITE}} {
+		values, ok := node.Property(setup.identifier)
+		if !ok || (handicap && setup.identifier == "AB") {
+			continue
+		}
+
+		positions, err := parsePointList(values)
+		if err == nil {
+			for _, position := range positions {
+				if err = board.Setup(position.X, position.Y, setup.status); err != nil {
+					break
+				}
+			}
+		}
+
+		if err != nil {
+			board.Undo(len(board.undoStack) - stackSize)
+			return 0, err
+		}
+	}
+
+	move, err := node.Move(board.BoardSize)
+	if err == nil && move != nil {
+		err = board.PlayMove(*move)
+	}
+
+	if err != nil {
+		board.Undo(len(board.undoStack) - stackSize)
+		return 0, fmt.Errorf("Can't play node %s: %v", node.ToString(), err)
+	}
+
+	return len(board.undoStack) - stackSize, nil
+}
+
+// Places the handicap of a root node with a HA property. HA is only informational, the stones are taken from AB.
+// Without AB (e.g. the handicap stones are played as moves) or with a malformed HA the root is replayed as normal
+// setup. Returns true if the handicap was placed
+func rootHandicap(board *AbstractBoard, node *Node) bool {
+	if node.Previous != nil || !node.HasProperty("HA") {
+		return false
+	}
+
+	// HA[0] and HA[1] mean no handicap stones
+	stones, err := strconv.Atoi(strings.TrimSpace(node.PropertyValue("HA")))
+	if err != nil || stones < 2 {
+		return false
+	}
+
+	values, ok := node.Property("AB")
+	if !ok {
+		return false
+	}
+
+	positions, err := parsePointList(values)
+	if err != nil {
+		return false
+	}
+
+	return board.SetFreeHandicap(positions) == nil
+}
+
+// Returns all nodes from the root to the given node
+func pathToNode(node *Node) []*Node {
+	path := []*Node{}
+
+	for ; node != nil; node = node.Previous {
+		path = append(path, node)
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path
+}
@@ -0,0 +1,110 @@
+package libaduk
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+// Tests if the board follows the cursor forward and back through a whole game
+func TestBoardCursorReplayMainLine(t *testing.T) {
+	sgfData, _ := ioutil.ReadFile(Testgame9x9)
+	cursor, err := NewBoardCursor(sgfData)
+
+	if err != nil {
+		t.Fatalf("Board cursor should be created but was %+v", err)
+	}
+
+	if cursor.Board().BoardSize != 9 {
+		t.Errorf("Board size should be 9 but was %d", cursor.Board().BoardSize)
+	}
+
+	moves := 0
+	for cursor.Current().numChildren > 0 {
+		if _, err := cursor.Next(0); err != nil {
+			t.Fatalf("Move %d should be playable but was %+v", moves+1, err)
+		}
+		moves++
+	}
+
+	// W[ce] captured the black stones at bd, be and cd is still black
+	board := cursor.Board()
+	if board.getStatus(2, 4) != WHITE || board.getStatus(2, 3) != BLACK || board.getStatus(6, 3) != BLACK {
+		t.Errorf("Unexpected final position:\n%s", board.ToString())
+	}
+
+	for i := 0; i < moves; i++ {
+		cursor.Previous()
+	}
+
+	if board.GetHash() != 0 || len(board.undoStack) != 0 {
+		t.Errorf("Board should be empty after going back to the root but was:\n%s", board.ToString())
+	}
+}
+
+// Tests if jumping between variations shows the right position
+func TestBoardCursorVariations(t *testing.T) {
+	sgfData, _ := ioutil.ReadFile(TestgameSmall)
+	cursor, _ := NewBoardCursor(sgfData)
+
+	cursor.Game(0)
+	cursor.Next(0)
+	cursor.Next(0)
+	cursor.Next(0) // B[gg]
+	cursor.Next(0) // W[cc]
+	hash := cursor.Board().GetHash()
+
+	cursor.Game(0)
+	cursor.Next(0)
+	cursor.Next(0)
+	cursor.Next(1) // B[cc]
+	if cursor.Board().getStatus(6, 6) != EMPTY || cursor.Board().getStatus(2, 2) != BLACK {
+		t.Errorf("Board should show variation B[cc] but was:\n%s", cursor.Board().ToString())
+	}
+
+	cursor.Game(0)
+	cursor.Next(0)
+	cursor.Next(0)
+	cursor.Next(0)
+	cursor.Next(0)
+	if cursor.Board().GetHash() != hash {
+		t.Errorf("Board should show the same position again but was:\n%s", cursor.Board().ToString())
+	}
+}
+
+// Tests setup properties and 19x19 boards
+func TestBoardCursorSetup(t *testing.T) {
+	cursor, err := NewBoardCursor([]byte("(;AB[aa:ab][sr]AW[ss];AE[aa]W[ba])"))
+
+	if err != nil {
+		t.Fatalf("Board cursor should be created but was %+v", err)
+	}
+
+	board := cursor.Board()
+	if board.BoardSize != 19 || board.getStatus(0, 1) != BLACK || board.getStatus(18, 17) != BLACK || board.getStatus(18, 18) != WHITE {
+		t.Errorf("Setup stones should be placed but was:\n%s", board.ToString())
+	}
+
+	cursor.Next(0)
+	if board.getStatus(0, 0) != EMPTY || board.getStatus(1, 0) != WHITE {
+		t.Errorf("AE should remove stone and W[ba] should be played but was:\n%s", board.ToString())
+	}
+
+	cursor.Previous()
+	if board.getStatus(0, 0) != BLACK || board.getStatus(1, 0) != EMPTY {
+		t.Errorf("Going back should restore the setup but was:\n%s", board.ToString())
+	}
+}
+
+// Tests if an illegal move keeps the cursor and the board unchanged
+func TestBoardCursorIllegalMove(t *testing.T) {
+	cursor, _ := NewBoardCursor([]byte("(;SZ[9];B[aa];W[aa])"))
+
+	cursor.Next(0)
+	if _, err := cursor.Next(0); err == nil {
+		t.Errorf("Playing on an occupied position should fail!")
+	}
+
+	if cursor.Current().PropertyValue("B") != "aa" || len(cursor.Board().undoStack) != 1 {
+		t.Errorf("Cursor should stay at B[aa] but was %s", cursor.Current().ToString())
+	}
+}
@@ -0,0 +1,100 @@
+package libaduk
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Method to count the score at the end of the game
+type ScoringMethod uint8
+
+const (
+	AREA_SCORING      ScoringMethod = iota // Stones on the board plus surrounded empty points
+	TERRITORY_SCORING                      // Surrounded empty points plus prisoners
+)
+
+// Compensation white gets for the handicap stones of black
+type HandicapCompensation uint8
+
+const (
+	NO_HANDICAP_COMPENSATION        HandicapCompensation = iota // Handicap stones don't count
+	HANDICAP_COMPENSATION_FULL                                  // White gets one point for every handicap stone
+	HANDICAP_COMPENSATION_MINUS_ONE                             // White gets one point for every handicap stone but the first
+)
+
+// Represents the rules a board is played with
+type Ruleset struct {
+	Name                 string
+	SuicideAllowed       bool // Suicide removes the own stones instead of being illegal
+	KoRule               KoRule
+	Scoring              ScoringMethod
+	HandicapCompensation HandicapCompensation
+	PassStones           bool // A player who passes hands a prisoner to the opponent
+	WhitePassesLast      bool // The game only ends after two passes if white passed last
+}
+
+var (
+	JapaneseRules = Ruleset{
+		Name:    "Japanese",
+		KoRule:  KO_SIMPLE,
+		Scoring: TERRITORY_SCORING,
+	}
+	ChineseRules = Ruleset{
+		Name:                 "Chinese",
+		KoRule:               KO_POSITIONAL_SUPERKO,
+		Scoring:              AREA_SCORING,
+		HandicapCompensation: HANDICAP_COMPENSATION_FULL,
+	}
+	AGARules = Ruleset{
+		Name:                 "AGA",
+		KoRule:               KO_SITUATIONAL_SUPERKO,
+		Scoring:              AREA_SCORING,
+		HandicapCompensation: HANDICAP_COMPENSATION_MINUS_ONE,
+		PassStones:           true,
+		WhitePassesLast:      true,
+	}
+	NewZealandRules = Ruleset{
+		Name:           "NZ",
+		SuicideAllowed: true,
+		KoRule:         KO_SITUATIONAL_SUPERKO,
+		Scoring:        AREA_SCORING,
+	}
+	TrompTaylorRules = Ruleset{
+		Name:           "Tromp-Taylor",
+		SuicideAllowed: true,
+		KoRule:         KO_POSITIONAL_SUPERKO,
+		Scoring:        AREA_SCORING,
+	}
+	IngRules = Ruleset{
+		Name:                 "GOE",
+		SuicideAllowed:       true,
+		KoRule:               KO_SITUATIONAL_SUPERKO,
+		Scoring:              AREA_SCORING,
+		HandicapCompensation: HANDICAP_COMPENSATION_FULL,
+	}
+)
+
+// Returns the ruleset for the value of a SGF RU property (e.g. "Japanese", "Chinese", "AGA", "NZ", "GOE")
+func RulesetFromSGF(value string) (Ruleset, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "japanese", "jp", "japan", "korean":
+		return JapaneseRules, nil
+	case "chinese", "cn", "china":
+		return ChineseRules, nil
+	case "aga":
+		return AGARules, nil
+	case "nz", "new zealand", "newzealand":
+		return NewZealandRules, nil
+	case "tromp-taylor", "tromp taylor", "tromptaylor":
+		return TrompTaylorRules, nil
+	case "goe", "ing":
+		return IngRules, nil
+	}
+
+	return Ruleset{}, fmt.Errorf("Unknown ruleset %q!", value)
+}
+
+// Returns the rules of the board
+func (board *AbstractBoard) Rules() Ruleset {
+	return board.rules
+}
@@ -0,0 +1,76 @@
+package libaduk
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+// Creates a board where black (0, 1) is a two stone suicide
+func newSuicideBoard(rules Ruleset) *AbstractBoard {
+	board, _ := NewBoardWithRules(9, rules)
+
+	board.Play(0, 0, BLACK)
+	board.Play(1, 0, WHITE)
+	board.Play(1, 1, WHITE)
+	board.Play(0, 2, WHITE)
+
+	return board
+}
+
+// Tests if multi stone suicide depends on the rules
+func TestMultiStoneSuicide(t *testing.T) {
+	board := newSuicideBoard(JapaneseRules)
+	if err := board.Play(0, 1, BLACK); err != ErrSuicide {
+		t.Errorf("Suicide should be %+v with japanese rules but was %+v", ErrSuicide, err)
+	}
+
+	board = newSuicideBoard(NewZealandRules)
+	hash := board.GetHash()
+
+	if err := board.Play(0, 1, BLACK); err != nil {
+		t.Fatalf("Suicide should be legal with NZ rules but was %+v", err)
+	}
+
+	if board.getStatus(0, 0) != EMPTY || board.getStatus(0, 1) != EMPTY {
+		t.Errorf("Suicide should remove own stones but was:\n%s", board.ToString())
+	}
+
+	board.Undo(1)
+	if board.getStatus(0, 0) != BLACK || board.getStatus(0, 1) != EMPTY || board.GetHash() != hash {
+		t.Errorf("Undo of a suicide should restore the position but was:\n%s", board.ToString())
+	}
+}
+
+// Tests if a single stone suicide is forbidden by positional superko
+func TestSingleStoneSuicideSuperko(t *testing.T) {
+	board, _ := NewBoardWithRules(9, TrompTaylorRules)
+
+	board.Play(1, 0, WHITE)
+	board.Play(0, 1, WHITE)
+
+	if err := board.Play(0, 0, BLACK); err != ErrPositionalSuperko {
+		t.Errorf("Single stone suicide should be %+v but was %+v", ErrPositionalSuperko, err)
+	}
+}
+
+// Tests mapping of SGF RU values to rulesets
+func TestRulesetFromSGF(t *testing.T) {
+	expected := map[string]string{"Japanese": "Japanese", "chinese": "Chinese", "AGA": "AGA", "NZ": "NZ", "GOE": "GOE"}
+
+	for value, name := range expected {
+		rules, err := RulesetFromSGF(value)
+		if err != nil || rules.Name != name {
+			t.Errorf("RU[%s] should be %s but was %+v (%+v)", value, name, rules, err)
+		}
+	}
+
+	if _, err := RulesetFromSGF("Unknown"); err == nil {
+		t.Errorf("Unknown rules should return an error!")
+	}
+
+	sgfData, _ := ioutil.ReadFile(TestgameSmall)
+	cursor, _ := NewBoardCursor(sgfData)
+	if cursor.Board().Rules().Name != "Japanese" {
+		t.Errorf("Board cursor should use the rules of RU[Japanese] but was %+v", cursor.Board().Rules())
+	}
+}
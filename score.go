@@ -0,0 +1,169 @@
+package libaduk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Represents the counted score of a finished position
+type Result struct {
+	Black          float64
+	White          float64 // Includes komi and handicap compensation
+	Method         ScoringMethod
+	BlackTerritory []Position
+	WhiteTerritory []Position
+}
+
+// Returns the winner of the game or EMPTY for a draw
+func (result Result) Winner() BoardStatus {
+	if result.Black > result.White {
+		return BLACK
+	}
+
+	if result.White > result.Black {
+		return WHITE
+	}
+
+	return EMPTY
+}
+
+// Returns the result as SGF RE value (e.g. "W+3.50" or "0" for a draw)
+func (result Result) String() string {
+	switch result.Winner() {
+	case BLACK:
+		return fmt.Sprintf("B+%.2f", result.Black-result.White)
+	case WHITE:
+		return fmt.Sprintf("W+%.2f", result.White-result.Black)
+	}
+
+	return "0"
+}
+
+// Checks if the result is the same as the given SGF RE value (e.g. "W+3.5" matches "W+3.50")
+func (result Result) Matches(re string) bool {
+	re = strings.TrimSpace(re)
+
+	if re == "0" || strings.EqualFold(re, "Draw") {
+		return result.Winner() == EMPTY
+	}
+
+	if len(re) < 3 || re[1] != '+' {
+		return false
+	}
+
+	margin, err := strconv.ParseFloat(re[2:], 64)
+	if err != nil {
+		return false
+	}
+
+	switch re[0] {
+	case 'B':
+		return result.Winner() == BLACK && result.Black-result.White == margin
+	case 'W':
+		return result.Winner() == WHITE && result.White-result.Black == margin
+	}
+
+	return false
+}
+
+// Counts the score of the current position with the scoring method of the board rules.
+// Dead stones are removed before counting and count as prisoners for territory scoring
+func (board *AbstractBoard) Score(deadStones []Position, komi float64) (*Result, error) {
+	status := make([]BoardStatus, len(board.data))
+	copy(status, board.data)
+
+	result := &Result{White: komi + float64(board.handicapCompensation()), Method: board.rules.Scoring}
+	prisoners := map[BoardStatus]int{BLACK: board.prisoners[BLACK], WHITE: board.prisoners[WHITE]}
+
+	// Remove dead stones, they are prisoners of the opponent
+	for _, dead := range deadStones {
+		if dead.X >= board.BoardSize || dead.Y >= board.BoardSize {
+			return nil, fmt.Errorf("Dead stone (%d, %d) is not on the board!", dead.X, dead.Y)
+		}
+
+		color := status[board.index(dead.X, dead.Y)]
+		if color != BLACK && color != WHITE {
+			return nil, fmt.Errorf("There is no dead stone at (%d, %d)!", dead.X, dead.Y)
+		}
+
+		status[board.index(dead.X, dead.Y)] = EMPTY
+		prisoners[color.invert()]++
+	}
+
+	// Find empty regions and check which colors are reached from them
+	visited := make([]bool, len(status))
+	for x := uint8(0); x < board.BoardSize; x++ {
+		for y := uint8(0); y < board.BoardSize; y++ {
+			if status[board.index(x, y)] != EMPTY || visited[board.index(x, y)] {
+				continue
+			}
+
+			region, reached := board.emptyRegion(status, visited, Position{x, y})
+
+			switch reached {
+			case BLACK:
+				result.BlackTerritory = append(result.BlackTerritory, region...)
+			case WHITE:
+				result.WhiteTerritory = append(result.WhiteTerritory, region...)
+			}
+		}
+	}
+
+	result.Black += float64(len(result.BlackTerritory))
+	result.White += float64(len(result.WhiteTerritory))
+
+	if result.Method == AREA_SCORING {
+		for _, color := range status {
+			if color == BLACK {
+				result.Black++
+			} else if color == WHITE {
+				result.White++
+			}
+		}
+	} else {
+		result.Black += float64(prisoners[BLACK])
+		result.White += float64(prisoners[WHITE])
+	}
+
+	return result, nil
+}
+
+// Returns the empty region containing start and the color that borders it
+// (EMPTY if it borders no or both colors)
+func (board *AbstractBoard) emptyRegion(status []BoardStatus, visited []bool, start Position) (region []Position, reached BoardStatus) {
+	reachesBlack, reachesWhite := false, false
+	stack := []Position{start}
+	visited[board.index(start.X, start.Y)] = true
+
+	for len(stack) > 0 {
+		position := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		region = append(region, position)
+
+		for _, neighbour := range board.getNeighbours(position.X, position.Y) {
+			index := board.index(neighbour.X, neighbour.Y)
+
+			switch status[index] {
+			case BLACK:
+				reachesBlack = true
+			case WHITE:
+				reachesWhite = true
+			case EMPTY:
+				if !visited[index] {
+					visited[index] = true
+					stack = append(stack, neighbour)
+				}
+			}
+		}
+	}
+
+	reached = EMPTY
+	if reachesBlack && !reachesWhite {
+		reached = BLACK
+	} else if reachesWhite && !reachesBlack {
+		reached = WHITE
+	}
+
+	return
+}
@@ -0,0 +1,69 @@
+package libaduk
+
+import (
+	"io/ioutil"
+	"strconv"
+	"testing"
+)
+
+// Tests if the recorded result of Batora-okao.sgf is recomputed with territory scoring
+func TestScoreTerritoryRecordedGame(t *testing.T) {
+	sgfData, _ := ioutil.ReadFile(Testgame9x9)
+	cursor, _ := NewBoardCursor(sgfData)
+	root := cursor.Current()
+
+	cursor.Game(0)
+	for cursor.Current().numChildren > 0 {
+		cursor.Next(0)
+	}
+
+	// Marked territory on occupied positions are dead stones
+	board := cursor.Board()
+	marked, _ := cursor.Current().Property("TB")
+	markedWhite, _ := cursor.Current().Property("TW")
+	territory, _ := parsePointList(append(marked, markedWhite...))
+
+	deadStones := []Position{}
+	for _, position := range territory {
+		if board.getStatus(position.X, position.Y) != EMPTY {
+			deadStones = append(deadStones, position)
+		}
+	}
+
+	komi, _ := strconv.ParseFloat(root.PropertyValue("KM"), 64)
+	result, err := board.Score(deadStones, komi)
+
+	if err != nil {
+		t.Fatalf("Score should be counted but was %+v", err)
+	}
+
+	if result.String() != root.PropertyValue("RE") || !result.Matches("W+3.5") {
+		t.Errorf("Result should be %s but was %s (%+v)", root.PropertyValue("RE"), result, result)
+	}
+}
+
+// Tests area scoring of a small position
+func TestScoreArea(t *testing.T) {
+	board, _ := NewBoardWithRules(5, ChineseRules)
+
+	// Black owns the two left columns, white the two right ones, the middle is neutral
+	for y := uint8(0); y < 5; y++ {
+		board.Play(1, y, BLACK)
+		board.Play(3, y, WHITE)
+	}
+	board.Play(2, 2, BLACK)
+	board.Play(4, 4, BLACK)
+
+	result, err := board.Score([]Position{{4, 4}}, 0.5)
+	if err != nil {
+		t.Fatalf("Score should be counted but was %+v", err)
+	}
+
+	if result.Black != 11 || result.White != 10.5 || result.String() != "B+0.50" {
+		t.Errorf("Result should be B+0.50 (11 : 10.5) but was %+v", result)
+	}
+
+	if _, err := board.Score([]Position{{2, 0}}, 0.5); err == nil {
+		t.Errorf("Empty dead stone position should be an error!")
+	}
+}
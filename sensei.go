@@ -0,0 +1,385 @@
+package libaduk
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Header of a Sensei's Library diagram, e.g. "$$Bc19m23 Title"
+var slHeader = regexp.MustCompile(`^\$\$([BW]?)(c?)(\d*)(?:m(\d+))?\s*(.*)$`)
+
+// Stone and mark shown by a diagram symbol
+type slMeaning struct {
+	stone BoardStatus
+	mark  Mark
+}
+
+// Stones and marks of the diagram symbols
+var slSymbols = map[byte]slMeaning{
+	'X': {BLACK, NO_MARK}, 'O': {WHITE, NO_MARK}, '.': {EMPTY, NO_MARK}, ',': {EMPTY, NO_MARK}, '_': {EMPTY, NO_MARK},
+	'B': {BLACK, CIRCLE}, 'W': {WHITE, CIRCLE}, 'C': {EMPTY, CIRCLE},
+	'#': {BLACK, SQUARE}, '@': {WHITE, SQUARE}, 'S': {EMPTY, SQUARE},
+	'Y': {BLACK, TRIANGLE}, 'Q': {WHITE, TRIANGLE}, 'T': {EMPTY, TRIANGLE},
+	'Z': {BLACK, CROSS}, 'P': {WHITE, CROSS}, 'M': {EMPTY, CROSS},
+}
+
+// Symbols written for the stones and marks, star points (',') are chosen by the writer
+var slSymbolOf = map[slMeaning]byte{
+	{BLACK, NO_MARK}: 'X', {WHITE, NO_MARK}: 'O', {EMPTY, NO_MARK}: '.',
+	{BLACK, CIRCLE}: 'B', {WHITE, CIRCLE}: 'W', {EMPTY, CIRCLE}: 'C',
+	{BLACK, SQUARE}: '#', {WHITE, SQUARE}: '@', {EMPTY, SQUARE}: 'S',
+	{BLACK, TRIANGLE}: 'Y', {WHITE, TRIANGLE}: 'Q', {EMPTY, TRIANGLE}: 'T',
+	{BLACK, CROSS}: 'Z', {WHITE, CROSS}: 'P', {EMPTY, CROSS}: 'M',
+}
+
+// SGF identifiers of the marks
+var markProperties = map[Mark]string{TRIANGLE: "TR", CIRCLE: "CR", SQUARE: "SQ", CROSS: "MA"}
+
+// Parses a Sensei's Library diagram ("$$" lines) into a game. The root node contains the stones and marks of the
+// diagram as setup, the numbered moves (1-9 and 0 for 10) follow as move nodes. Diagrams without all four edges
+// are placed at their edges on a 19x19 board, unless the header contains the board size
+func ParseSLDiagram(text string) (*Cursor, error) {
+	lines := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "$$") {
+			lines = append(lines, line)
+		}
+	}
+
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("No diagram found!")
+	}
+
+	header := slHeader.FindStringSubmatch(lines[0])
+	if header == nil {
+		return nil, fmt.Errorf("Invalid diagram header %q!", lines[0])
+	}
+
+	// Collect rows and edges
+	rows := []string{}
+	top, bottom, left, right := false, false, false, false
+	for _, line := range lines[1:] {
+		line = strings.Replace(strings.TrimPrefix(line, "$$"), " ", "", -1)
+
+		if line == "" || line[0] == '{' {
+			continue
+		}
+
+		if strings.Trim(line, "-+") == "" {
+			if len(rows) == 0 {
+				top = true
+			} else {
+				bottom = true
+			}
+			continue
+		}
+
+		if line[0] == '|' {
+			left = true
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "|") {
+			right = true
+			line = line[:len(line)-1]
+		}
+
+		if len(rows) > 0 && len(line) != len(rows[0]) {
+			return nil, fmt.Errorf("Diagram rows have different lengths!")
+		}
+		rows = append(rows, line)
+	}
+
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return nil, fmt.Errorf("Diagram has no rows!")
+	}
+
+	size, err := slBoardSize(header[3], len(rows[0]), len(rows), top && bottom, left && right)
+	if err != nil {
+		return nil, err
+	}
+
+	// Partial diagrams are placed at their edges
+	offsetX, offsetY := 0, 0
+	if right && !left {
+		offsetX = size - len(rows[0])
+	}
+	if bottom && !top {
+		offsetY = size - len(rows)
+	}
+
+	first := BLACK
+	if header[1] == "W" {
+		first = WHITE
+	}
+
+	root := NewNode(nil)
+	root.SetProperty("GM", "1")
+	root.SetProperty("FF", "4")
+	root.SetProperty("SZ", strconv.Itoa(size))
+	if header[5] != "" {
+		root.SetProperty("GN", header[5])
+	}
+
+	moves := map[int]string{}
+	for y, row := range rows {
+		for x := 0; x < len(row); x++ {
+			point := positionToPoint(Position{uint8(offsetX + x), uint8(offsetY + y)})
+			symbol := row[x]
+
+			switch {
+			case symbol >= '1' && symbol <= '9':
+				moves[int(symbol-'0')] = point
+			case symbol == '0':
+				moves[10] = point
+			case symbol >= 'a' && symbol <= 'z':
+				root.AddPropertyValue("LB", point+":"+string(symbol))
+			default:
+				meaning, ok := slSymbols[symbol]
+				if !ok {
+					return nil, fmt.Errorf("Invalid diagram symbol %q!", symbol)
+				}
+
+				if meaning.stone == BLACK {
+					root.AddPropertyValue("AB", point)
+				} else if meaning.stone == WHITE {
+					root.AddPropertyValue("AW", point)
+				}
+
+				if meaning.mark != NO_MARK {
+					root.AddPropertyValue(markProperties[meaning.mark], point)
+				}
+			}
+		}
+	}
+
+	root.SetProperty("PL", GTPColorString(first))
+
+	numbers := []int{}
+	for number := range moves {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+
+	last := root
+	for _, number := range numbers {
+		color := first
+		if number%2 == 0 {
+			color = first.invert()
+		}
+
+		node := NewNode(last)
+		node.SetProperty(GTPColorString(color), moves[number])
+		if last == root && header[4] != "" && header[4] != "1" {
+			node.SetProperty("MN", header[4])
+		}
+
+		last.Next = node
+		last.numChildren = 1
+		last = node
+	}
+
+	cursor := &Cursor{rootNode: root, currentNode: root}
+	if err := cursor.syncBoard(root); err != nil {
+		return nil, err
+	}
+
+	return cursor, nil
+}
+
+// Returns the board size of a diagram with the given number of columns and rows
+func slBoardSize(header string, columns int, rows int, allRows bool, allColumns bool) (int, error) {
+	size := 19
+
+	switch {
+	case header != "":
+		size, _ = strconv.Atoi(header)
+	case allRows && allColumns && rows != columns:
+		return 0, fmt.Errorf("Only square boards are supported!")
+	case allRows:
+		size = rows
+	case allColumns:
+		size = columns
+	}
+
+	if size < 1 || size > 52 || columns > size || rows > size {
+		return 0, fmt.Errorf("Invalid diagram size %dx%d!", columns, rows)
+	}
+
+	return size, nil
+}
+
+// Returns the current board position as Sensei's Library diagram
+func (board *AbstractBoard) SLDiagram(options RenderOptions) (string, error) {
+	return writeSLDiagram(boardDiagram(board), board.NextColor(), 1, options)
+}
+
+// Returns the board at the current node with the marks and letter labels of the node as Sensei's Library diagram
+func (cursor *Cursor) SLDiagram(options RenderOptions) (string, error) {
+	d, err := cursorDiagram(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	return writeSLDiagram(d, cursor.board.NextColor(), 1, options)
+}
+
+// Returns the moves from to to (at most 10) on the path to the current node as numbered Sensei's Library diagram.
+// Moves on occupied positions and passes are listed below the diagram
+func (cursor *Cursor) SLKifuDiagram(from int, to int, options RenderOptions) (string, error) {
+	if to-from >= 10 {
+		return "", fmt.Errorf("Diagrams can only show 10 moves!")
+	}
+
+	d, err := kifuDiagram(cursor, from, to)
+	if err != nil {
+		return "", err
+	}
+
+	// Numbers start at 1 in every diagram (0 is 10), the header contains the first move number
+	label := func(number int) string {
+		return strconv.Itoa((number - from + 1) % 10)
+	}
+
+	for position, value := range d.labels {
+		number, _ := strconv.Atoi(value)
+		d.labels[position] = label(number)
+	}
+
+	options.LastMove = false
+	diagram, err := writeSLDiagram(d, moveColor(cursor.currentNode, from), from, options)
+	if err != nil {
+		return "", err
+	}
+
+	// Vertices are not part of the diagram syntax
+	for _, note := range d.notes {
+		diagram += note.text(label, false, d.size) + "\n"
+	}
+
+	return diagram, nil
+}
+
+// Returns the color of move number n on the path to the node
+func moveColor(node *Node, n int) BoardStatus {
+	path := pathToNode(node)
+	boardSize, _ := path[0].BoardSize()
+	number := 0
+
+	for _, node := range path {
+		if move, _ := node.Move(boardSize); move != nil {
+			number++
+			if number == n {
+				return move.Color
+			}
+		}
+	}
+
+	return BLACK
+}
+
+// Writes the diagram in Sensei's Library syntax, first is the color of the first move and moveNumber its number
+func writeSLDiagram(d *diagram, first BoardStatus, moveNumber int, options RenderOptions) (string, error) {
+	if options.Region != nil {
+		if err := d.crop(*options.Region); err != nil {
+			return "", err
+		}
+	}
+
+	header := "$$" + GTPColorString(first)
+	if options.Coordinates {
+		header += "c"
+	}
+
+	// Partial diagrams need the board size to be placed correctly
+	full := d.region.From.X == 0 && d.region.From.Y == 0 && d.region.To.X == d.size-1 && d.region.To.Y == d.size-1
+	if !full || options.Coordinates {
+		header += strconv.Itoa(int(d.size))
+	}
+
+	if moveNumber > 1 {
+		header += "m" + strconv.Itoa(moveNumber)
+	}
+
+	left := d.region.From.X == 0
+	right := d.region.To.X == d.size-1
+	columns := int(d.region.To.X-d.region.From.X) + 1
+
+	width := 2*columns - 1
+	if left {
+		width += 2
+	}
+	if right {
+		width += 2
+	}
+
+	edge := []byte(strings.Repeat("-", width))
+	if left {
+		edge[0] = '+'
+	}
+	if right {
+		edge[width-1] = '+'
+	}
+
+	stars := d.starPoints()
+	lines := []string{header}
+
+	if d.region.From.Y == 0 {
+		lines = append(lines, "$$ "+string(edge))
+	}
+
+	for y := d.region.From.Y; y <= d.region.To.Y; y++ {
+		cells := []string{}
+		if left {
+			cells = append(cells, "|")
+		}
+
+		for x := d.region.From.X; x <= d.region.To.X; x++ {
+			position := Position{x, y}
+			symbol := slSymbol(d, position, options.LastMove)
+			if symbol == '.' && containsPosition(stars, position) {
+				symbol = ','
+			}
+			cells = append(cells, string(symbol))
+		}
+
+		if right {
+			cells = append(cells, "|")
+		}
+		lines = append(lines, "$$ "+strings.Join(cells, " "))
+	}
+
+	if d.region.To.Y == d.size-1 {
+		lines = append(lines, "$$ "+string(edge))
+	}
+
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+// Returns the diagram symbol of the position, labels that can't be shown are left out
+func slSymbol(d *diagram, position Position, lastMove bool) byte {
+	stone := d.stoneAt(position)
+
+	if label, ok := d.labels[position]; ok && len(label) == 1 {
+		if stone != EMPTY && label[0] >= '0' && label[0] <= '9' {
+			return label[0]
+		}
+		if stone == EMPTY && label[0] >= 'a' && label[0] <= 'z' {
+			return label[0]
+		}
+	}
+
+	mark := d.marks[position]
+	if mark == NO_MARK && lastMove && d.lastMove != nil && *d.lastMove == position {
+		mark = CIRCLE
+	}
+
+	if symbol, ok := slSymbolOf[slMeaning{stone, mark}]; ok {
+		return symbol
+	}
+
+	return '.'
+}
@@ -0,0 +1,133 @@
+package libaduk
+
+import (
+	"strings"
+	"testing"
+)
+
+// Tests parsing a full board diagram with marks, labels and moves
+func TestParseSLDiagram(t *testing.T) {
+	cursor, err := ParseSLDiagram(`
+Black to live
+$$W Problem
+$$ +---------------+
+$$ | . . . . . . . |
+$$ | . X X O . . . |
+$$ | . B 1 O . a . |
+$$ | . 2 X O . . . |
+$$ | . . . . C . . |
+$$ | . . Q . . . . |
+$$ | . . . . . . . |
+$$ +---------------+
+`)
+
+	if err != nil {
+		t.Fatalf("Diagram should be parsed but was %+v", err)
+	}
+
+	root := cursor.Current()
+	if root.PropertyValue("SZ") != "7" || root.PropertyValue("GN") != "Problem" || root.PropertyValue("PL") != "W" {
+		t.Errorf("Root should have size, name and player but was %s", root.ToString())
+	}
+
+	board := cursor.Board()
+	if board.getStatus(1, 2) != BLACK || board.getStatus(2, 5) != WHITE || board.getStatus(2, 2) != EMPTY {
+		t.Errorf("Setup stones should be placed:\n%s", board.ToString())
+	}
+
+	if root.PropertyValue("CR") != "bc" || root.PropertyValue("TR") != "cf" || root.PropertyValue("LB") != "fc:a" {
+		t.Errorf("Marks should be added but was %s", root.ToString())
+	}
+
+	cursor.Next(0)
+	cursor.Next(0)
+	if cursor.Board().getStatus(2, 2) != WHITE || cursor.Board().getStatus(1, 3) != BLACK {
+		t.Errorf("Moves should be played by white first:\n%s", cursor.Board().ToString())
+	}
+}
+
+// Tests if partial diagrams are placed at their edges
+func TestParseSLDiagramCorner(t *testing.T) {
+	cursor, err := ParseSLDiagram("$$\n$$ . . . . |\n$$ . X O . |\n$$ . . . . |\n$$ ---------+")
+	if err != nil {
+		t.Fatalf("Diagram should be parsed but was %+v", err)
+	}
+
+	if cursor.Board().BoardSize != 19 || cursor.Board().getStatus(16, 17) != BLACK || cursor.Board().getStatus(17, 17) != WHITE {
+		t.Errorf("Diagram should be placed in the lower right corner:\n%s", cursor.Board().ToString())
+	}
+
+	for _, invalid := range []string{"no diagram", "$$\n$$ | . . |\n$$ | . |", "$$\n$$ | . K |", "$$5\n$$ . . . . . . ."} {
+		if _, err := ParseSLDiagram(invalid); err == nil {
+			t.Errorf("Diagram %q should be invalid!", invalid)
+		}
+	}
+}
+
+// Tests exporting boards and parsing them back
+func TestSLDiagramRoundTrip(t *testing.T) {
+	board, _ := NewBoard(9)
+	board.Play(2, 2, BLACK)
+	board.Play(6, 6, WHITE)
+
+	diagram, err := board.SLDiagram(RenderOptions{LastMove: true})
+	if err != nil {
+		t.Fatalf("Diagram should be written but was %+v", err)
+	}
+
+	expected := "$$B\n$$ +-------------------+\n$$ | . . . . . . . . . |\n$$ | . . . . . . . . . |\n$$ | . . X . . . , . . |\n"
+	if !strings.HasPrefix(diagram, expected) || !strings.Contains(diagram, "$$ | . . , . . . W . . |\n") {
+		t.Errorf("Diagram should start with\n%s\nbut was\n%s", expected, diagram)
+	}
+
+	cursor, err := ParseSLDiagram(diagram)
+	if err != nil || cursor.Board().GetHash() != board.GetHash() {
+		t.Errorf("Parsed diagram should show the same position but was %+v", err)
+	}
+
+	diagram, _ = board.SLDiagram(RenderOptions{Region: &Region{Position{0, 0}, Position{3, 3}}})
+	if diagram != "$$B9\n$$ +--------\n$$ | . . . .\n$$ | . . . .\n$$ | . . X .\n$$ | . . . .\n" {
+		t.Errorf("Cropped diagram was\n%s", diagram)
+	}
+}
+
+// Tests numbered diagrams of a game
+func TestSLKifuDiagram(t *testing.T) {
+	cursor, _ := NewBoardCursor([]byte(kifuTestGame))
+	for i := 0; i < 12; i++ {
+		cursor.Next(0)
+	}
+
+	diagram, err := cursor.SLKifuDiagram(3, 12, RenderOptions{})
+	if err != nil {
+		t.Fatalf("Diagram should be written but was %+v", err)
+	}
+
+	expected := "$$Bm3\n$$ +-----------+\n$$ | . X O . . |\n$$ | 1 6 5 2 . |\n$$ | . 3 4 . . |\n$$ | . . . . 8 |\n$$ | . . . . 7 |\n$$ +-----------+\n9 at 5\n0: White pass\n"
+	if diagram != expected {
+		t.Errorf("Diagram should be\n%s\nbut was\n%s", expected, diagram)
+	}
+
+	// Move 7 was played before the diagram, so its stone has no number
+	diagram, _ = cursor.SLKifuDiagram(8, 12, RenderOptions{})
+	if !strings.HasSuffix(diagram, "$$ +-----------+\n4 at a stone already on the board\n5: White pass\n") {
+		t.Errorf("Notes should not contain vertices but were\n%s", diagram)
+	}
+
+	if _, err := cursor.SLKifuDiagram(1, 11, RenderOptions{}); err == nil {
+		t.Errorf("Diagrams with more than 10 moves should fail!")
+	}
+}
+
+// Tests that every written symbol is read back as the same stone and mark
+func TestSLSymbolsRoundTrip(t *testing.T) {
+	for meaning, symbol := range slSymbolOf {
+		if slSymbols[symbol] != meaning {
+			t.Errorf("Symbol %q should be %+v but was %+v", symbol, meaning, slSymbols[symbol])
+		}
+	}
+
+	if len(slSymbolOf) != len(slSymbols)-2 {
+		t.Errorf("Every stone and mark should have a symbol but were %d", len(slSymbolOf))
+	}
+}
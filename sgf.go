@@ -1,8 +1,12 @@
 package libaduk
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"log"
+	"strconv"
+	"strings"
 )
 
 const (
@@ -23,6 +27,7 @@ func parse(sgf string) (*Node, error) {
 	nodeStartIndex := -1
 	lastParsedType := SEQUENCE_END
 	isInProperty := false
+	var err error
 
 	// range on string handles unicode automatically
 	for i, value := range sgf {
@@ -59,7 +64,9 @@ func parse(sgf string) (*Node, error) {
 
 			// Safe sgf string to current node before creating a new one
 			if lastParsedType != SEQUENCE_END && nodeStartIndex != -1 {
-				lastNode.sgfData = sgf[nodeStartIndex:i]
+				if lastNode.properties, err = parseProperties(sgf[nodeStartIndex:i]); err != nil {
+					return nil, err
+				}
 			}
 
 			// Create new Node for Sequence
@@ -95,7 +102,9 @@ func parse(sgf string) (*Node, error) {
 		if value == SEQUENCE_END {
 			// Safe sgf string to current node before creating a new one
 			if lastParsedType != SEQUENCE_END && nodeStartIndex != -1 {
-				lastNode.sgfData = sgf[nodeStartIndex:i]
+				if lastNode.properties, err = parseProperties(sgf[nodeStartIndex:i]); err != nil {
+					return nil, err
+				}
 			}
 
 			// If we had sequences in the stack, set current node to last in stack
@@ -114,7 +123,9 @@ func parse(sgf string) (*Node, error) {
 		if value == NODE_START {
 			if nodeStartIndex != -1 {
 				// Safe sgf string to last node before creating a new one
-				lastNode.sgfData = sgf[nodeStartIndex:i]
+				if lastNode.properties, err = parseProperties(sgf[nodeStartIndex:i]); err != nil {
+					return nil, err
+				}
 
 				// Create new node and update current
 				node := NewNode(lastNode)
@@ -133,6 +144,11 @@ func parse(sgf string) (*Node, error) {
 		return nil, fmt.Errorf("Malformed SGF (Still in Property or Sequence after parsing)!")
 	}
 
+	// Without any sequence there is no game in the sgf (e.g. an empty file)
+	if tree.Next == nil {
+		return nil, fmt.Errorf("No game found!")
+	}
+
 	// Last Node should now be the last item from the sequence stack, so it should be the root
 	// So we remove all ties from the first node to the root node
 	node := tree.Next
@@ -146,3 +162,286 @@ func parse(sgf string) (*Node, error) {
 
 	return tree.Next, nil
 }
+
+// Parses the properties of a single node (e.g. ";B[aa]C[A comment]")
+func parseProperties(data string) ([]Property, error) {
+	properties := []Property{}
+	i := 0
+
+	if i < len(data) && data[i] == NODE_START {
+		i++
+	}
+
+	for {
+		i = skipWhitespace(data, i)
+		if i >= len(data) {
+			break
+		}
+
+		// Read identifier, lowercase letters are ignored (FF[3] style identifiers like "AddBlack")
+		identifier := ""
+		for ; i < len(data) && isLetter(data[i]); i++ {
+			if data[i] >= 'A' && data[i] <= 'Z' {
+				identifier += string(data[i])
+			}
+		}
+
+		if identifier == "" {
+			return nil, fmt.Errorf("Malformed SGF (Invalid property identifier in node %q)!", data)
+		}
+
+		// Read all values of the property
+		values := []string{}
+		for i = skipWhitespace(data, i); i < len(data) && data[i] == PROPERTY_START; i = skipWhitespace(data, i) {
+			end := findPropertyEnd(data, i+1)
+			if end == -1 {
+				return nil, fmt.Errorf("Malformed SGF (Property %s is not closed)!", identifier)
+			}
+
+			values = append(values, unescapeValue(data[i+1:end]))
+			i = end + 1
+		}
+
+		if len(values) == 0 {
+			return nil, fmt.Errorf("Malformed SGF (Property %s has no value)!", identifier)
+		}
+
+		// Identifiers should be unique within a node, we merge duplicate ones
+		merged := false
+		for j := range properties {
+			if properties[j].Identifier == identifier {
+				properties[j].Values = append(properties[j].Values, values...)
+				merged = true
+			}
+		}
+
+		if !merged {
+			properties = append(properties, Property{identifier, values})
+		}
+	}
+
+	return properties, nil
+}
+
+// Returns the index of the unescaped PROPERTY_END starting at start or -1
+func findPropertyEnd(data string, start int) int {
+	for i := start; i < len(data); i++ {
+		if data[i] == '\\' {
+			i++
+		} else if data[i] == PROPERTY_END {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Decodes an escaped property value. Escaped linebreaks (soft linebreaks) are removed
+func unescapeValue(value string) string {
+	if strings.IndexByte(value, '\\') == -1 {
+		return value
+	}
+
+	result := make([]byte, 0, len(value))
+
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 >= len(value) {
+			result = append(result, value[i])
+			continue
+		}
+
+		i++
+
+		if value[i] == '\n' || value[i] == '\r' {
+			// Soft linebreak, skip "\r\n" and "\n\r" combinations completely
+			if i+1 < len(value) && (value[i+1] == '\n' || value[i+1] == '\r') && value[i+1] != value[i] {
+				i++
+			}
+			continue
+		}
+
+		result = append(result, value[i])
+	}
+
+	return string(result)
+}
+
+// Returns the index of the first non whitespace character starting at i
+func skipWhitespace(data string, i int) int {
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t' || data[i] == '\n' || data[i] == '\r') {
+		i++
+	}
+
+	return i
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z')
+}
+
+// Writes a collection of game trees, one for every root node
+func writeCollection(w io.Writer, roots ...*Node) error {
+	buffer := bufio.NewWriter(w)
+
+	for _, root := range roots {
+		writeGameTree(buffer, root)
+		buffer.WriteByte('\n')
+	}
+
+	return buffer.Flush()
+}
+
+// Writes the game tree starting at node with all its variations
+func writeGameTree(buffer *bufio.Writer, node *Node) {
+	buffer.WriteByte(SEQUENCE_START)
+
+	// Follow the sequence until there is a variation, variations are written recursively
+	for {
+		writeNode(buffer, node)
+		buffer.WriteByte('\n')
+
+		if node.numChildren == 1 {
+			node = node.Next
+			continue
+		}
+
+		for child := node.Next; child != nil; child = child.Down {
+			writeGameTree(buffer, child)
+		}
+		break
+	}
+
+	buffer.WriteByte(SEQUENCE_END)
+}
+
+// Writes a single node with all of its properties
+func writeNode(w io.ByteWriter, node *Node) {
+	w.WriteByte(NODE_START)
+
+	for _, property := range node.properties {
+		for i := 0; i < len(property.Identifier); i++ {
+			w.WriteByte(property.Identifier[i])
+		}
+
+		// Every property needs a value, properties without values are written with an empty one
+		values := property.Values
+		if len(values) == 0 {
+			values = []string{""}
+		}
+
+		for _, value := range values {
+			w.WriteByte(PROPERTY_START)
+			value = escapeValue(value)
+			for i := 0; i < len(value); i++ {
+				w.WriteByte(value[i])
+			}
+			w.WriteByte(PROPERTY_END)
+		}
+	}
+}
+
+// Escapes all characters of a property value that have a special meaning
+func escapeValue(value string) string {
+	if strings.IndexAny(value, "\\]") == -1 {
+		return value
+	}
+
+	result := make([]byte, 0, len(value)+2)
+
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' || value[i] == PROPERTY_END {
+			result = append(result, '\\')
+		}
+		result = append(result, value[i])
+	}
+
+	return string(result)
+}
+
+// Converts a SGF point (e.g. "cd") to a position
+func parsePoint(value string) (Position, error) {
+	if len(value) != 2 {
+		return Position{}, fmt.Errorf("Invalid SGF point %q!", value)
+	}
+
+	x, errX := parseCoordinate(value[0])
+	y, errY := parseCoordinate(value[1])
+	if errX != nil || errY != nil {
+		return Position{}, fmt.Errorf("Invalid SGF point %q!", value)
+	}
+
+	return Position{x, y}, nil
+}
+
+// Converts a single SGF coordinate character to its number
+func parseCoordinate(c byte) (uint8, error) {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a', nil
+	}
+
+	if c >= 'A' && c <= 'Z' {
+		return c - 'A' + 26, nil
+	}
+
+	return 0, fmt.Errorf("Invalid SGF coordinate %q!", c)
+}
+
+// Converts a list of SGF points to positions, compressed point lists (e.g. "aa:cc") are expanded
+func parsePointList(values []string) ([]Position, error) {
+	positions := []Position{}
+
+	for _, value := range values {
+		if len(value) == 5 && value[2] == ':' {
+			from, errFrom := parsePoint(value[:2])
+			to, errTo := parsePoint(value[3:])
+			if errFrom != nil || errTo != nil || from.X > to.X || from.Y > to.Y {
+				return nil, fmt.Errorf("Invalid SGF point list %q!", value)
+			}
+
+			for x := int(from.X); x <= int(to.X); x++ {
+				for y := int(from.Y); y <= int(to.Y); y++ {
+					positions = append(positions, Position{uint8(x), uint8(y)})
+				}
+			}
+			continue
+		}
+
+		position, err := parsePoint(value)
+		if err != nil {
+			return nil, err
+		}
+
+		positions = append(positions, position)
+	}
+
+	return positions, nil
+}
+
+// Returns the SGF point for the given position
+func positionToPoint(position Position) string {
+	return string([]byte{coordinateToByte(position.X), coordinateToByte(position.Y)})
+}
+
+// Converts a coordinate number to its SGF character
+func coordinateToByte(c uint8) byte {
+	if c >= 26 {
+		return 'A' + c - 26
+	}
+
+	return 'a' + c
+}
+
+// Checks if the move value is a pass ("" or "tt" on boards up to 19x19)
+func isPassValue(value string, boardSize uint8) bool {
+	return value == "" || (value == "tt" && boardSize <= 19)
+}
+
+// Parses the value of the SZ property, only square boards are supported
+func parseBoardSize(value string) (uint8, error) {
+	size, err := strconv.Atoi(value)
+	if err != nil || size < 1 || size > 52 {
+		return 0, fmt.Errorf("Invalid or unsupported board size %q!", value)
+	}
+
+	return uint8(size), nil
+}
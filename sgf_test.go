@@ -1,7 +1,9 @@
 package libaduk
 
 import (
+	"bytes"
 	"io/ioutil"
+	"reflect"
 	"testing"
 )
 
@@ -10,6 +12,7 @@ const (
 	TestgameEasy           = "testing/Easy.sgf"
 	TestgameSmall          = "testing/Small.sgf"
 	TestgameSmallMalformed = "testing/SmallMalformed.sgf"
+	TestgameKogo           = "testing/Kogo's Joseki Dictionary.sgf"
 )
 
 // Small.sgf has this structure:
@@ -24,6 +27,7 @@ func TestSgfReadAndNumChildrenAndNextAndPrevious(t *testing.T) {
 	sgfData, _ := ioutil.ReadFile(TestgameSmall)
 	cursor, _ := NewCursor(sgfData)
 	_, _ = cursor.Game(0)
+	cursor.Next(0) // go to 1
 
 	if cursor.Current().numChildren != 1 {
 		t.Errorf("Node 1 should have 1 children but was: %+v", cursor.Current())
@@ -62,23 +66,200 @@ func TestEasySgfRead(t *testing.T) {
 	}
 }
 
-// Tests if we can get the correct root games
+// Tests if we can get the correct root games, Batora-okao.sgf is one game with two variations at the root
 func TestMultiRootGameAndGetRootNode(t *testing.T) {
 	sgfData, _ := ioutil.ReadFile(Testgame9x9)
 	cursor, _ := NewCursor(sgfData)
 
 	_, _ = cursor.Game(0)
-	if cursor.Current() == nil {
-		t.Errorf("Cursor should have a root Node!")
+	if cursor.Current() == nil || cursor.Current().numChildren != 2 {
+		t.Errorf("Cursor should be at the root with 2 variations!")
 	}
 
-	_, _ = cursor.Game(1)
-	if cursor.Current() == nil {
-		t.Errorf("Cursor should have at least a second root Node!")
+	_, err := cursor.Game(1)
+	if err == nil {
+		t.Errorf("There should be no second root Node!")
 	}
 
-	_, err := cursor.Game(2)
-	if err == nil {
-		t.Errorf("There should be no third root Node!")
+	cursor, _ = NewCursor([]byte("(;SZ[9];B[aa])(;SZ[19])"))
+	if root, _ := cursor.Game(1); root == nil || root.PropertyValue("SZ") != "19" || cursor.NumGames() != 2 {
+		t.Errorf("Second game should be SZ[19] but was %+v", root)
+	}
+}
+
+// Tests if node properties are parsed and escapes are decoded
+func TestSgfParseProperties(t *testing.T) {
+	sgfData, _ := ioutil.ReadFile(TestgameSmall)
+	cursor, _ := NewCursor(sgfData)
+
+	root := cursor.Current()
+	if root.PropertyValue("SZ") != "9" || root.PropertyValue("RU") != "Japanese" {
+		t.Errorf("Root should have SZ[9] and RU[Japanese] but had %+v", root.Properties())
+	}
+
+	cursor.Game(0)
+	cursor.Next(0)
+	cursor.Next(0)
+	cursor.Next(1)
+	if comment := cursor.Current().PropertyValue("C"); comment != "A [second] (comment)" {
+		t.Errorf("Comment should be unescaped but was %q", comment)
+	}
+
+	cursor.Previous()
+	cursor.Next(2)
+	if comment := cursor.Current().PropertyValue("C"); comment != `And \[a \\\\third\\\\] comment)` {
+		t.Errorf("Comment should be unescaped but was %q", comment)
 	}
+
+	if values, ok := cursor.Current().Property("B"); !ok || len(values) != 1 || values[0] != "ec" {
+		t.Errorf("Move should be B[ec] but was %+v", values)
+	}
+}
+
+// Tests parsing of multiple values, soft linebreaks and FF[3] style identifiers
+func TestParsePropertyValues(t *testing.T) {
+	properties, err := parseProperties(";AddBlack[aa][bb] [cc]\nC[soft\\\nbreak]")
+
+	if err != nil {
+		t.Fatalf("Properties should be parsed but was %+v", err)
+	}
+
+	if len(properties) != 2 || properties[0].Identifier != "AB" || len(properties[0].Values) != 3 {
+		t.Errorf("Expected AB with 3 values but was %+v", properties)
+	}
+
+	if properties[1].Values[0] != "softbreak" {
+		t.Errorf("Soft linebreak should be removed but was %q", properties[1].Values[0])
+	}
+
+	if _, err := parseProperties(";B"); err == nil {
+		t.Errorf("Property without value should be malformed!")
+	}
+}
+
+// Tests setting and removing node properties
+func TestSetAndRemoveProperty(t *testing.T) {
+	node := NewNode(nil)
+
+	node.SetProperty("C", "first")
+	node.SetProperty("B", "aa")
+	node.SetProperty("C", "second")
+
+	if node.PropertyValue("C") != "second" || len(node.Properties()) != 2 {
+		t.Errorf("Property C should have been replaced but was %+v", node.Properties())
+	}
+
+	node.RemoveProperty("C")
+	if node.HasProperty("C") || !node.HasProperty("B") {
+		t.Errorf("Property C should have been removed but was %+v", node.Properties())
+	}
+}
+
+// Tests that returned property values can't change the node
+func TestPropertyReturnsCopy(t *testing.T) {
+	node := NewNode(nil)
+	node.SetProperty("AB", "aa", "bb")
+
+	values, _ := node.Property("AB")
+	values[0] = "cc"
+	node.Properties()[0].Values[1] = "dd"
+
+	if values, _ := node.Property("AB"); values[0] != "aa" || values[1] != "bb" {
+		t.Errorf("Property AB should be unchanged but was %+v", values)
+	}
+
+	values = []string{"aa"}
+	node.SetProperty("AW", values...)
+	node.AddPropertyValue("TR", values...)
+	values[0] = "cc"
+
+	if node.PropertyValue("AW") != "aa" || node.PropertyValue("TR") != "aa" {
+		t.Errorf("Values of the caller should be copied but were %+v", node.Properties())
+	}
+}
+
+// Tests if parsing and writing sgf files gives the same trees again
+func TestSgfWriteRoundTrip(t *testing.T) {
+	for _, file := range []string{TestgameSmall, TestgameKogo, Testgame9x9} {
+		sgfData, _ := ioutil.ReadFile(file)
+		cursor, err := NewCursor(sgfData)
+		if err != nil {
+			t.Fatalf("%s should be parsed but was %+v", file, err)
+		}
+
+		var buffer bytes.Buffer
+		if err := cursor.WriteSGF(&buffer); err != nil {
+			t.Fatalf("%s should be written but was %+v", file, err)
+		}
+
+		written, err := NewCursor(buffer.Bytes())
+		if err != nil {
+			t.Fatalf("Written %s should be parsed again but was %+v", file, err)
+		}
+
+		if !equalTrees(cursor.rootNode, written.rootNode) {
+			t.Errorf("Written tree of %s differs from the original!", file)
+		}
+	}
+}
+
+// Tests writing multiple games and escaping of values
+func TestSgfWriteCollectionAndEscapes(t *testing.T) {
+	cursor, _ := NewCursor([]byte("(;SZ[9]C[a \\] b\\\\];B[aa](;W[bb])(;W[cc]))(;SZ[19])"))
+
+	if cursor.NumGames() != 2 {
+		t.Fatalf("Collection should have 2 games but had %d", cursor.NumGames())
+	}
+
+	var buffer bytes.Buffer
+	cursor.WriteSGF(&buffer)
+
+	expected := "(;SZ[9]C[a \\] b\\\\]\n;B[aa]\n(;W[bb]\n)(;W[cc]\n))\n(;SZ[19]\n)\n"
+	if buffer.String() != expected {
+		t.Errorf("Written sgf should be %q but was %q", expected, buffer.String())
+	}
+
+	root, _ := cursor.Game(1)
+	if data, _ := root.MarshalSGF(); string(data) != "(;SZ[19]\n)\n" {
+		t.Errorf("Marshalled second game was %q", data)
+	}
+
+	if _, err := cursor.Game(2); err == nil {
+		t.Errorf("There should be no third game!")
+	}
+
+	// Properties without values get an empty value, so the output can be parsed again
+	node := NewNode(nil)
+	node.SetProperty("C")
+	if data, _ := node.MarshalSGF(); string(data) != "(;C[]\n)\n" {
+		t.Errorf("Property without values should be written as C[] but was %q", data)
+	} else if _, err := NewCursor(data); err != nil {
+		t.Errorf("Written sgf should be parsed again but was %+v", err)
+	}
+}
+
+// Compares two trees by structure and properties
+func equalTrees(a *Node, b *Node) bool {
+	stack := [][2]*Node{{a, b}}
+
+	for len(stack) > 0 {
+		pair := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if pair[0] == nil || pair[1] == nil {
+			if pair[0] != pair[1] {
+				return false
+			}
+			continue
+		}
+
+		if pair[0].numChildren != pair[1].numChildren || pair[0].level != pair[1].level ||
+			!reflect.DeepEqual(pair[0].properties, pair[1].properties) {
+			return false
+		}
+
+		stack = append(stack, [2]*Node{pair[0].Next, pair[1].Next}, [2]*Node{pair[0].Down, pair[1].Down})
+	}
+
+	return true
 }
@@ -0,0 +1,243 @@
+package libaduk
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+)
+
+// Options for rendering diagrams
+type RenderOptions struct {
+	Size        int     // Maximum width and height of the board in pixels, 400 if not set
+	Coordinates bool    // Draw coordinates (A-T and 1-19) at the top and left side
+	LastMove    bool    // Mark the last move
+	Region      *Region // Only draw the given part of the board
+}
+
+const (
+	defaultRenderSize = 400
+	boardColor        = "#dcb35c"
+)
+
+// Draws the current board position as SVG
+func (board *AbstractBoard) RenderSVG(w io.Writer, options RenderOptions) error {
+	return renderSVG(w, boardDiagram(board), options)
+}
+
+// Draws the board at the current node with the markup of the node (LB, TR, CR, SQ, MA, AR and LN) as SVG
+func (cursor *Cursor) RenderSVG(w io.Writer, options RenderOptions) error {
+	d, err := cursorDiagram(cursor)
+	if err != nil {
+		return err
+	}
+
+	return renderSVG(w, d, options)
+}
+
+// Draws the moves from to to on the path to the current node as numbered SVG diagram.
+// Moves on occupied positions and passes are listed beside the board
+func (cursor *Cursor) RenderKifuSVG(w io.Writer, from int, to int, options RenderOptions) error {
+	d, err := kifuDiagram(cursor, from, to)
+	if err != nil {
+		return err
+	}
+
+	options.LastMove = false
+
+	return renderSVG(w, d, options)
+}
+
+// Sizes of a diagram in pixels
+type diagramLayout struct {
+	cell    int // Distance of two lines
+	originX int // Center of the upper left position of the region
+	originY int
+	width   int
+	height  int
+}
+
+// Calculates the pixel sizes of the diagram
+func newDiagramLayout(d *diagram, options RenderOptions) diagramLayout {
+	size := options.Size
+	if size <= 0 {
+		size = defaultRenderSize
+	}
+
+	columns := int(d.region.To.X-d.region.From.X) + 1
+	rows := int(d.region.To.Y-d.region.From.Y) + 1
+	border := 1
+	if options.Coordinates {
+		border = 2
+	}
+
+	cell := size / (maxInt(columns, rows) + border)
+	if cell < 4 {
+		cell = 4
+	}
+
+	layout := diagramLayout{
+		cell:    cell,
+		originX: cell * border,
+		originY: cell * border,
+		width:   cell * (columns + border),
+		height:  cell * (rows + border),
+	}
+
+	// Notes are written beside the board
+	if len(d.notes) > 0 {
+		layout.width += cell * 4
+		layout.height = maxInt(layout.height, cell*(len(d.notes)+1))
+	}
+
+	return layout
+}
+
+// Returns the pixel center of the position
+func (layout diagramLayout) point(d *diagram, position Position) (int, int) {
+	return layout.originX + layout.cell*int(position.X-d.region.From.X), layout.originY + layout.cell*int(position.Y-d.region.From.Y)
+}
+
+// Writes the diagram as SVG
+func renderSVG(w io.Writer, d *diagram, options RenderOptions) error {
+	if options.Coordinates && d.size > maxVertexBoardSize {
+		return fmt.Errorf("Coordinates are only supported up to %dx%d!", maxVertexBoardSize, maxVertexBoardSize)
+	}
+
+	if options.Region != nil {
+		if err := d.crop(*options.Region); err != nil {
+			return err
+		}
+	}
+
+	layout := newDiagramLayout(d, options)
+	cell := layout.cell
+	out := bufio.NewWriter(w)
+
+	fmt.Fprintf(out, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", layout.width, layout.height, layout.width, layout.height)
+	fmt.Fprintf(out, `<defs><marker id="arrowhead" markerWidth="6" markerHeight="6" refX="5" refY="3" orient="auto"><path d="M0,0 L6,3 L0,6 Z"/></marker></defs>`+"\n")
+	fmt.Fprintf(out, `<rect width="%d" height="%d" fill="%s"/>`+"\n", layout.width, layout.height, boardColor)
+
+	// Lines continue half a position where the region doesn't end at the border of the board
+	left, top := layout.point(d, d.region.From)
+	right, bottom := layout.point(d, d.region.To)
+	extendLeft, extendTop, extendRight, extendBottom := 0, 0, 0, 0
+	if d.region.From.X > 0 {
+		extendLeft = cell / 2
+	}
+	if d.region.From.Y > 0 {
+		extendTop = cell / 2
+	}
+	if d.region.To.X < d.size-1 {
+		extendRight = cell / 2
+	}
+	if d.region.To.Y < d.size-1 {
+		extendBottom = cell / 2
+	}
+
+	for x := d.region.From.X; x <= d.region.To.X; x++ {
+		px, _ := layout.point(d, Position{x, 0})
+		fmt.Fprintf(out, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`+"\n", px, top-extendTop, px, bottom+extendBottom)
+	}
+	for y := d.region.From.Y; y <= d.region.To.Y; y++ {
+		_, py := layout.point(d, Position{0, y})
+		fmt.Fprintf(out, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`+"\n", left-extendLeft, py, right+extendRight, py)
+	}
+
+	for _, star := range d.starPoints() {
+		if d.contains(star) {
+			px, py := layout.point(d, star)
+			fmt.Fprintf(out, `<circle cx="%d" cy="%d" r="%d" fill="black"/>`+"\n", px, py, maxInt(cell/10, 1))
+		}
+	}
+
+	if options.Coordinates {
+		fontSize := cell * 2 / 5
+		for x := d.region.From.X; x <= d.region.To.X; x++ {
+			px, _ := layout.point(d, Position{x, 0})
+			fmt.Fprintf(out, `<text x="%d" y="%d" font-size="%d" text-anchor="middle">%c</text>`+"\n", px, cell/2+fontSize/2, fontSize, columnLetter(x))
+		}
+		for y := d.region.From.Y; y <= d.region.To.Y; y++ {
+			_, py := layout.point(d, Position{0, y})
+			fmt.Fprintf(out, `<text x="%d" y="%d" font-size="%d" text-anchor="middle">%d</text>`+"\n", cell/2, py+fontSize/2, fontSize, int(d.size)-int(y))
+		}
+	}
+
+	for x := d.region.From.X; x <= d.region.To.X; x++ {
+		for y := d.region.From.Y; y <= d.region.To.Y; y++ {
+			position := Position{x, y}
+			px, py := layout.point(d, position)
+			stone := d.stoneAt(position)
+
+			switch stone {
+			case BLACK:
+				fmt.Fprintf(out, `<circle cx="%d" cy="%d" r="%d" fill="black" stroke="black"/>`+"\n", px, py, cell*12/25)
+			case WHITE:
+				fmt.Fprintf(out, `<circle cx="%d" cy="%d" r="%d" fill="white" stroke="black"/>`+"\n", px, py, cell*12/25)
+			}
+
+			writeSVGMarkup(out, d, position, px, py, cell, options.LastMove)
+		}
+	}
+
+	for _, line := range d.lines {
+		if !d.contains(line.from) || !d.contains(line.to) {
+			continue
+		}
+
+		x1, y1 := layout.point(d, line.from)
+		x2, y2 := layout.point(d, line.to)
+		marker := ""
+		if line.arrow {
+			marker = ` marker-end="url(#arrowhead)"`
+		}
+		fmt.Fprintf(out, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black" stroke-width="2"%s/>`+"\n", x1, y1, x2, y2, marker)
+	}
+
+	for i, note := range d.noteTexts() {
+		fmt.Fprintf(out, `<text x="%d" y="%d" font-size="%d">%s</text>`+"\n", layout.width-cell*4+cell/2, cell*(i+1), cell*2/5, html.EscapeString(note))
+	}
+
+	fmt.Fprintf(out, "</svg>\n")
+
+	return out.Flush()
+}
+
+// Writes the label, mark or last move marker of the position
+func writeSVGMarkup(out *bufio.Writer, d *diagram, position Position, px int, py int, cell int, lastMove bool) {
+	stone := d.stoneAt(position)
+	color := "black"
+	if stone == BLACK {
+		color = "white"
+	}
+
+	if label, ok := d.labels[position]; ok {
+		// Labels on empty positions hide the lines
+		if stone == EMPTY {
+			fmt.Fprintf(out, `<circle cx="%d" cy="%d" r="%d" fill="%s"/>`+"\n", px, py, cell*2/5, boardColor)
+		}
+
+		fontSize := cell / 2
+		if len(label) > 2 {
+			fontSize = cell * 2 / 5
+		}
+		fmt.Fprintf(out, `<text x="%d" y="%d" font-size="%d" text-anchor="middle" fill="%s">%s</text>`+"\n", px, py+fontSize*2/5, fontSize, color, html.EscapeString(label))
+		return
+	}
+
+	r := cell / 4
+	switch d.marks[position] {
+	case TRIANGLE:
+		fmt.Fprintf(out, `<polygon points="%d,%d %d,%d %d,%d" fill="none" stroke="%s" stroke-width="2"/>`+"\n", px, py-r, px-r, py+r*3/4, px+r, py+r*3/4, color)
+	case CIRCLE:
+		fmt.Fprintf(out, `<circle cx="%d" cy="%d" r="%d" fill="none" stroke="%s" stroke-width="2"/>`+"\n", px, py, r, color)
+	case SQUARE:
+		fmt.Fprintf(out, `<rect x="%d" y="%d" width="%d" height="%d" fill="none" stroke="%s" stroke-width="2"/>`+"\n", px-r, py-r, 2*r, 2*r, color)
+	case CROSS:
+		fmt.Fprintf(out, `<path d="M%d,%d L%d,%d M%d,%d L%d,%d" stroke="%s" stroke-width="2"/>`+"\n", px-r, py-r, px+r, py+r, px+r, py-r, px-r, py+r, color)
+	default:
+		if lastMove && d.lastMove != nil && *d.lastMove == position {
+			fmt.Fprintf(out, `<circle cx="%d" cy="%d" r="%d" fill="%s"/>`+"\n", px, py, maxInt(cell/6, 1), color)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package libaduk
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// Tests rendering a board with coordinates and last move marker
+func TestBoardRenderSVG(t *testing.T) {
+	board, _ := NewBoard(9)
+	board.Play(2, 6, BLACK)
+	board.Play(6, 2, WHITE)
+
+	var buffer bytes.Buffer
+	if err := board.RenderSVG(&buffer, RenderOptions{Size: 220, Coordinates: true, LastMove: true}); err != nil {
+		t.Fatalf("Board should be rendered but was %+v", err)
+	}
+
+	svg := buffer.String()
+	for _, expected := range []string{
+		`<svg xmlns="http://www.w3.org/2000/svg" width="220" height="220"`,
+		`<circle cx="80" cy="160" r="9" fill="black" stroke="black"/>`,
+		`<circle cx="160" cy="80" r="9" fill="white" stroke="black"/>`,
+		`<circle cx="160" cy="80" r="3" fill="black"/>`,
+		`>J</text>`,
+		`>9</text>`,
+		"</svg>\n",
+	} {
+		if !strings.Contains(svg, expected) {
+			t.Errorf("SVG should contain %q but was:\n%s", expected, svg)
+		}
+	}
+
+	if strings.Count(svg, "<line") != 18 || strings.Count(svg, `r="2" fill="black"/>`) != 5 {
+		t.Errorf("SVG should contain 18 lines and 5 star points:\n%s", svg)
+	}
+}
+
+// Tests rendering the markup of a node and cropped numbered diagrams
+func TestCursorRenderSVG(t *testing.T) {
+	cursor, _ := NewBoardCursor([]byte("(;SZ[9];B[cc]LB[ee:<A>]TR[cc]AR[aa:bb])"))
+	cursor.Next(0)
+
+	var buffer bytes.Buffer
+	cursor.RenderSVG(&buffer, RenderOptions{Size: 200})
+	svg := buffer.String()
+
+	if !strings.Contains(svg, "&lt;A&gt;</text>") || !strings.Contains(svg, `<polygon`) || !strings.Contains(svg, `marker-end="url(#arrowhead)"`) {
+		t.Errorf("SVG should contain the markup but was:\n%s", svg)
+	}
+
+	cursor, _ = NewBoardCursor([]byte(kifuTestGame))
+	for i := 0; i < 12; i++ {
+		cursor.Next(0)
+	}
+
+	buffer.Reset()
+	err := cursor.RenderKifuSVG(&buffer, 1, 12, RenderOptions{Size: 100, Region: &Region{Position{0, 0}, Position{3, 3}}})
+	svg = buffer.String()
+
+	if err != nil || !strings.Contains(svg, ">11 at 7</text>") || strings.Count(svg, "<line") != 8 {
+		t.Errorf("Kifu should be rendered cropped but was %+v:\n%s", err, svg)
+	}
+
+	if err := cursor.RenderKifuSVG(&buffer, 1, 12, RenderOptions{Region: &Region{Position{0, 0}, Position{5, 5}}}); err == nil {
+		t.Errorf("Region outside of the board should fail!")
+	}
+}
@@ -0,0 +1,281 @@
+package libaduk
+
+import (
+	"fmt"
+	"strings"
+)
+
+// One of the 8 symmetries of the board. Mirrored symmetries flip the x axis before rotating clockwise
+type Symmetry uint8
+
+const (
+	IDENTITY Symmetry = iota
+	ROTATE_90
+	ROTATE_180
+	ROTATE_270
+	MIRROR
+	MIRROR_ROTATE_90
+	MIRROR_ROTATE_180
+	MIRROR_ROTATE_270
+)
+
+// All symmetries of the board
+var Symmetries = []Symmetry{IDENTITY, ROTATE_90, ROTATE_180, ROTATE_270, MIRROR, MIRROR_ROTATE_90, MIRROR_ROTATE_180, MIRROR_ROTATE_270}
+
+// Returns the symmetry that reverts this one
+func (sym Symmetry) Inverse() Symmetry {
+	// Mirrored symmetries are their own inverse
+	if sym >= MIRROR || sym == IDENTITY {
+		return sym
+	}
+
+	return 4 - sym
+}
+
+// Returns the position transformed by sym on a board of the given size, passes are not changed
+func (position Position) Transform(sym Symmetry, boardSize uint8) Position {
+	if position.X == 255 && position.Y == 255 {
+		return position
+	}
+
+	x, y := position.X, position.Y
+	max := boardSize - 1
+
+	if sym >= MIRROR {
+		x = max - x
+	}
+
+	// Rotate clockwise
+	for i := Symmetry(0); i < sym%4; i++ {
+		x, y = max-y, x
+	}
+
+	return Position{x, y}
+}
+
+// Returns the move and its captures transformed by sym on a board of the given size
+func (move Move) Transform(sym Symmetry, boardSize uint8) Move {
+	position := Position{move.X, move.Y}.Transform(sym, boardSize)
+	move.X, move.Y = position.X, position.Y
+
+	if move.Captures != nil {
+		captures := make([]Position, len(move.Captures))
+		for i, capture := range move.Captures {
+			captures[i] = capture.Transform(sym, boardSize)
+		}
+		move.Captures = captures
+	}
+
+	return move
+}
+
+// Returns a copy of the current position transformed by sym. The copy has the same rules but no undostack
+func (board *AbstractBoard) Transform(sym Symmetry) *AbstractBoard {
+	transformed, _ := NewBoardWithRules(board.BoardSize, board.rules)
+
+	for index, status := range board.data {
+		if status == EMPTY {
+			continue
+		}
+
+		position := board.position(index).Transform(sym, board.BoardSize)
+		transformed.setStatus(position.X, position.Y, status)
+		transformed.zobrist.Hash(position.X, position.Y, status)
+	}
+
+	transformed.rebuildChains()
+	transformed.trail = []trailEntry{}
+	transformed.resetHistory()
+	transformed.prisoners[BLACK] = board.prisoners[BLACK]
+	transformed.prisoners[WHITE] = board.prisoners[WHITE]
+
+	return transformed
+}
+
+// Returns the smallest hash of all 8 symmetries of the current position and the symmetry that produces it
+func (board *AbstractBoard) CanonicalHash() (uint64, Symmetry) {
+	var hashes [8]uint64
+
+	for index, status := range board.data {
+		if status != BLACK && status != WHITE {
+			continue
+		}
+
+		color := 0
+		if status == WHITE {
+			color = 1
+		}
+
+		position := board.position(index)
+		for _, sym := range Symmetries {
+			transformed := position.Transform(sym, board.BoardSize)
+			hashes[sym] ^= board.zobrist.table[board.index(transformed.X, transformed.Y)][color]
+		}
+	}
+
+	canonical := IDENTITY
+	for _, sym := range Symmetries {
+		if hashes[sym] < hashes[canonical] {
+			canonical = sym
+		}
+	}
+
+	return hashes[canonical], canonical
+}
+
+// Properties with points, lists of points or point compositions as values
+var pointProperties = map[string]bool{
+	"B": true, "W": true, "AB": true, "AW": true, "AE": true, "TR": true, "CR": true, "SQ": true, "MA": true,
+	"SL": true, "TB": true, "TW": true, "DD": true, "VW": true, "LB": true, "AR": true, "LN": true,
+}
+
+// New values of a point property
+type propertyUpdate struct {
+	node   *Node
+	index  int
+	values []string
+}
+
+// Transforms all points of the node and its children by sym, nothing is changed if a value is invalid
+func (node *Node) Transform(sym Symmetry, boardSize uint8) error {
+	updates, err := node.transformUpdates(sym, boardSize)
+	if err != nil {
+		return err
+	}
+
+	applyPropertyUpdates(updates)
+
+	return nil
+}
+
+// Returns the transformed point properties of the node and its children without changing them
+func (node *Node) transformUpdates(sym Symmetry, boardSize uint8) ([]propertyUpdate, error) {
+	updates := []propertyUpdate{}
+	stack := []*Node{node}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		for i, property := range current.properties {
+			if !pointProperties[property.Identifier] {
+				continue
+			}
+
+			values := make([]string, len(property.Values))
+			for j, value := range property.Values {
+				transformed, err := transformPointValue(property.Identifier, value, sym, boardSize)
+				if err != nil {
+					return nil, err
+				}
+				values[j] = transformed
+			}
+			updates = append(updates, propertyUpdate{current, i, values})
+		}
+
+		if current.Next != nil {
+			stack = append(stack, current.Next)
+		}
+		if current != node && current.Down != nil {
+			stack = append(stack, current.Down)
+		}
+	}
+
+	return updates, nil
+}
+
+// Sets the new values of the properties
+func applyPropertyUpdates(updates []propertyUpdate) {
+	for _, update := range updates {
+		update.node.properties[update.index].Values = update.values
+	}
+}
+
+// Transforms a single point property value (e.g. "aa", "aa:cc" or "aa:label")
+func transformPointValue(identifier string, value string, sym Symmetry, boardSize uint8) (string, error) {
+	if (identifier == "B" || identifier == "W") && isPassValue(value, boardSize) {
+		return value, nil
+	}
+
+	transform := func(point string) (Position, error) {
+		position, err := parsePoint(point)
+		if err != nil {
+			return position, err
+		}
+		if position.X >= boardSize || position.Y >= boardSize {
+			return position, fmt.Errorf("Point %q is not on the board!", point)
+		}
+		return position.Transform(sym, boardSize), nil
+	}
+
+	// Empty values are allowed for some properties, e.g. VW[] shows the whole board again
+	if value == "" {
+		return value, nil
+	}
+
+	if len(value) < 2 {
+		return "", fmt.Errorf("Invalid point value %q!", value)
+	}
+
+	first, err := transform(value[:2])
+	if err != nil {
+		return "", err
+	}
+
+	if len(value) == 2 {
+		return positionToPoint(first), nil
+	}
+
+	if len(value) < 3 || value[2] != ':' {
+		return "", fmt.Errorf("Invalid point value %q!", value)
+	}
+
+	// Labels only have one point
+	if identifier == "LB" {
+		return positionToPoint(first) + value[2:], nil
+	}
+
+	second, err := transform(value[3:])
+	if err != nil {
+		return "", err
+	}
+
+	// Arrows and lines keep their direction, rectangles need new upper left and lower right corners
+	if identifier == "AR" || identifier == "LN" {
+		return positionToPoint(first) + ":" + positionToPoint(second), nil
+	}
+
+	from := Position{uint8(minInt(int(first.X), int(second.X))), uint8(minInt(int(first.Y), int(second.Y)))}
+	to := Position{first.X + second.X - from.X, first.Y + second.Y - from.Y}
+
+	return strings.Join([]string{positionToPoint(from), positionToPoint(to)}, ":"), nil
+}
+
+// Transforms all games of the cursor by sym, the board of a board cursor is updated.
+// Nothing is changed if a value of any game is invalid
+func (cursor *Cursor) Transform(sym Symmetry) error {
+	updates := []propertyUpdate{}
+
+	for root := cursor.rootNode; root != nil; root = root.Down {
+		boardSize, err := root.BoardSize()
+		if err != nil {
+			return err
+		}
+
+		gameUpdates, err := root.transformUpdates(sym, boardSize)
+		if err != nil {
+			return err
+		}
+		updates = append(updates, gameUpdates...)
+	}
+
+	applyPropertyUpdates(updates)
+
+	if cursor.board != nil {
+		cursor.board = nil
+		cursor.boardPath = nil
+		return cursor.syncBoard(cursor.currentNode)
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package libaduk
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+// Tests if all symmetries are different and can be reverted
+func TestSymmetryTransformAndInverse(t *testing.T) {
+	position := Position{1, 2}
+	seen := map[Position]bool{}
+
+	for _, sym := range Symmetries {
+		transformed := position.Transform(sym, 19)
+		seen[transformed] = true
+
+		if back := transformed.Transform(sym.Inverse(), 19); back != position {
+			t.Errorf("Inverse of %d should restore %+v but was %+v", sym, position, back)
+		}
+	}
+
+	if len(seen) != 8 {
+		t.Errorf("All 8 symmetries should give different positions but were %+v", seen)
+	}
+
+	if rotated := position.Transform(ROTATE_90, 19); rotated != (Position{16, 1}) {
+		t.Errorf("Clockwise rotation of (1, 2) should be (16, 1) but was %+v", rotated)
+	}
+
+	if pass := (Position{255, 255}).Transform(MIRROR, 19); pass != (Position{255, 255}) {
+		t.Errorf("Pass should not be transformed but was %+v", pass)
+	}
+}
+
+// Tests if transformed boards have the same canonical hash
+func TestBoardTransformAndCanonicalHash(t *testing.T) {
+	board, _ := NewBoard(19)
+	board.Play(2, 3, BLACK)
+	board.Play(15, 16, WHITE)
+	board.Play(3, 3, BLACK)
+
+	canonical, canonicalSym := board.CanonicalHash()
+
+	if board.Transform(canonicalSym).GetHash() != canonical {
+		t.Errorf("Transforming by the canonical symmetry should give the canonical hash!")
+	}
+
+	for _, sym := range Symmetries {
+		transformed := board.Transform(sym)
+
+		if transformed.StoneAt(Position{2, 3}.Transform(sym, 19)) != BLACK || len(transformed.Groups()) != 2 {
+			t.Errorf("Transformed board %d is wrong:\n%s", sym, transformed.ToString())
+		}
+
+		if hash, _ := transformed.CanonicalHash(); hash != canonical {
+			t.Errorf("Canonical hash of symmetry %d should be %d but was %d", sym, canonical, hash)
+		}
+	}
+}
+
+// Tests transforming whole game trees
+func TestTreeTransform(t *testing.T) {
+	sgfData, _ := ioutil.ReadFile(TestgameSmall)
+	original, _ := NewCursor(sgfData)
+	cursor, _ := NewCursor(sgfData)
+
+	cursor.Transform(MIRROR_ROTATE_90)
+	cursor.Game(0)
+	cursor.Next(0)
+	if move := cursor.Current().PropertyValue("B"); move != "gc" {
+		t.Errorf("B[gc] is on the diagonal and should stay but was %s", move)
+	}
+
+	cursor.Transform(MIRROR_ROTATE_90)
+	if !equalTrees(original.rootNode, cursor.rootNode) {
+		t.Errorf("Transforming twice by a mirror symmetry should give the original tree!")
+	}
+
+	node := NewNode(nil)
+	node.SetProperty("LB", "ab:A")
+	node.SetProperty("SQ", "aa:bc")
+	node.SetProperty("AR", "aa:cc")
+	node.SetProperty("W", "")
+	node.Transform(ROTATE_90, 9)
+
+	if node.PropertyValue("LB") != "ha:A" || node.PropertyValue("SQ") != "ga:ib" || node.PropertyValue("AR") != "ia:gc" || node.PropertyValue("W") != "" {
+		t.Errorf("Wrong transformed properties %+v", node.Properties())
+	}
+}
+
+// Tests empty point values and that invalid values don't change the tree
+func TestTreeTransformEmptyAndInvalidValues(t *testing.T) {
+	cursor, _ := NewCursor([]byte("(;SZ[9]VW[]DD[];B[ab];W[zz])(;SZ[9];B[ab])"))
+
+	if err := cursor.Transform(ROTATE_90); err == nil {
+		t.Fatalf("W[zz] is not on the board and should fail!")
+	}
+
+	root := cursor.Current()
+	if root.Next.PropertyValue("B") != "ab" || root.Down.Next.PropertyValue("B") != "ab" {
+		t.Errorf("Tree should be unchanged after a failed transform!")
+	}
+
+	cursor, _ = NewCursor([]byte("(;SZ[9]VW[]DD[];B[ab])"))
+	if err := cursor.Transform(ROTATE_90); err != nil || cursor.Current().Next.PropertyValue("B") != "ha" {
+		t.Errorf("Empty VW and DD should be kept but was %+v", err)
+	}
+
+	if values, _ := cursor.Current().Property("VW"); len(values) != 1 || values[0] != "" {
+		t.Errorf("VW should stay empty but was %+v", values)
+	}
+}
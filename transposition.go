@@ -0,0 +1,169 @@
+package libaduk
+
+// Options for the transposition analysis
+type TranspositionOptions struct {
+	SideToMove bool // Positions only match if the same color has to move
+}
+
+// A position that is reached by different nodes of the tree
+type Transposition struct {
+	Hash   uint64
+	ToMove BoardStatus // Color that has to move, EMPTY if the side to move is ignored
+	Nodes  []*Node     // Nodes that reach the position, in depth-first pre-order
+}
+
+// Position of the tree in the DAG view, all nodes with the same position are merged
+type DAGNode struct {
+	Hash     uint64
+	ToMove   BoardStatus // Color that has to move, EMPTY if the side to move is ignored
+	Nodes    []*Node     // Tree nodes with this position, nodes that don't change the position are included
+	Parents  []*DAGNode
+	Children []*DAGNode
+	entries  []*Node // Nodes that reach the position from a different position or start a game
+}
+
+// Key of a position
+type positionKey struct {
+	boardSize uint8
+	hash      uint64
+	toMove    BoardStatus
+}
+
+// Replays every variation of all games and returns the positions that are reached by more than one node.
+// Nodes that return to a position of the path to them (e.g. comments, passes or a ko) don't count as transposition.
+// Variations with illegal moves are skipped
+func (cursor *Cursor) FindTranspositions(options TranspositionOptions) ([]Transposition, error) {
+	_, positions, err := cursor.positionDAG(options)
+	if err != nil {
+		return nil, err
+	}
+
+	transpositions := []Transposition{}
+	for _, position := range positions {
+		if len(position.entries) > 1 {
+			transpositions = append(transpositions, Transposition{position.Hash, position.ToMove, position.entries})
+		}
+	}
+
+	return transpositions, nil
+}
+
+// Replays every variation of all games and returns the root positions of a graph in which all nodes with the same
+// position are merged. Transposed positions have more than one parent. Moves that would create a cycle
+// (e.g. two passes or a ko) don't add an edge
+func (cursor *Cursor) TranspositionDAG(options TranspositionOptions) ([]*DAGNode, error) {
+	roots, _, err := cursor.positionDAG(options)
+
+	return roots, err
+}
+
+// Builds the DAG of all games, returns the roots and all positions in the order they were reached first
+func (cursor *Cursor) positionDAG(options TranspositionOptions) ([]*DAGNode, []*DAGNode, error) {
+	positions := map[positionKey]*DAGNode{}
+	ordered := []*DAGNode{}
+	roots := []*DAGNode{}
+
+	for root := cursor.rootNode; root != nil; root = root.Down {
+		board, err := newRootBoard(root)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Number of undostack entries and position of every node on the path to the visited node
+		undo := []int{}
+		path := []*DAGNode{}
+
+		Walk(root, func(node *Node, depth int) WalkAction {
+			for len(undo) > depth {
+				board.Undo(undo[len(undo)-1])
+				undo = undo[:len(undo)-1]
+				path = path[:len(path)-1]
+			}
+
+			count, err := applyNode(board, node)
+			if err != nil {
+				return WALK_SKIP_CHILDREN
+			}
+
+			key := positionKey{board.BoardSize, board.GetHash(), EMPTY}
+			if options.SideToMove {
+				key.toMove = sideToMove(board, node)
+			}
+
+			position, ok := positions[key]
+			if !ok {
+				position = &DAGNode{Hash: key.hash, ToMove: key.toMove}
+				positions[key] = position
+				ordered = append(ordered, position)
+			}
+			position.Nodes = append(position.Nodes, node)
+
+			if depth == 0 {
+				position.entries = append(position.entries, node)
+				if !containsDAGNode(roots, position) {
+					roots = append(roots, position)
+				}
+			} else if parent := path[depth-1]; !containsDAGNode(path, position) {
+				position.entries = append(position.entries, node)
+				if !containsDAGNode(parent.Children, position) && !position.reaches(parent) {
+					parent.Children = append(parent.Children, position)
+					position.Parents = append(position.Parents, parent)
+				}
+			}
+
+			undo = append(undo, count)
+			path = append(path, position)
+
+			return WALK_CONTINUE
+		})
+	}
+
+	return roots, ordered, nil
+}
+
+// Checks if target can be reached by following the children of the position
+func (position *DAGNode) reaches(target *DAGNode) bool {
+	visited := map[*DAGNode]bool{position: true}
+	stack := []*DAGNode{position}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if current == target {
+			return true
+		}
+
+		for _, child := range current.Children {
+			if !visited[child] {
+				visited[child] = true
+				stack = append(stack, child)
+			}
+		}
+	}
+
+	return false
+}
+
+// Returns the color that moves after the node, a PL property overrides the turn order
+func sideToMove(board *AbstractBoard, node *Node) BoardStatus {
+	switch node.PropertyValue("PL") {
+	case "B":
+		return BLACK
+	case "W":
+		return WHITE
+	}
+
+	return board.NextColor()
+}
+
+// Checks if node is part of nodes
+func containsDAGNode(nodes []*DAGNode, node *DAGNode) bool {
+	for _, n := range nodes {
+		if n == node {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,97 @@
+package libaduk
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+// Two move orders that reach the same position, with different players to move
+const transpositionTestGame = "(;SZ[9](;B[aa];W[bb];B[cc]C[First];W[dd])(;B[cc];W[bb];B[aa]C[Second])(;W[bb];B[aa]))"
+
+// Tests finding transpositions with and without the side to move
+func TestCursorFindTranspositions(t *testing.T) {
+	cursor, _ := NewCursor([]byte(transpositionTestGame))
+
+	transpositions, err := cursor.FindTranspositions(TranspositionOptions{})
+	if err != nil {
+		t.Fatalf("Tree should be analyzed but was %+v", err)
+	}
+
+	// B[aa] W[bb] is reached twice, B[aa] W[bb] B[cc] twice
+	if len(transpositions) != 2 || len(transpositions[0].Nodes) != 2 || len(transpositions[1].Nodes) != 2 {
+		t.Fatalf("Two transpositions should be found but were %+v", transpositions)
+	}
+
+	comments := transpositions[1].Nodes[0].PropertyValue("C") + " " + transpositions[1].Nodes[1].PropertyValue("C")
+	if comments != "First Second" || transpositions[1].ToMove != EMPTY {
+		t.Errorf("Transposition should contain both commented nodes but was %s", comments)
+	}
+
+	transpositions, _ = cursor.FindTranspositions(TranspositionOptions{SideToMove: true})
+	if len(transpositions) != 1 || transpositions[0].ToMove != WHITE {
+		t.Errorf("Only the position with white to move should be transposed but was %+v", transpositions)
+	}
+}
+
+// Tests the DAG view of the tree
+func TestCursorTranspositionDAG(t *testing.T) {
+	cursor, _ := NewCursor([]byte(transpositionTestGame))
+
+	roots, err := cursor.TranspositionDAG(TranspositionOptions{})
+	if err != nil || len(roots) != 1 || len(roots[0].Children) != 3 {
+		t.Fatalf("DAG should have one root with 3 children but was %+v", err)
+	}
+
+	// Both move orders of the first and second variation are merged after three moves
+	merged := roots[0].Children[0].Children[0].Children[0]
+	if len(merged.Parents) != 2 || len(merged.Nodes) != 2 || len(merged.Children) != 1 {
+		t.Errorf("Merged position should have two parents but was %+v", merged)
+	}
+
+	if roots[0].Children[2].Children[0] != roots[0].Children[0].Children[0] {
+		t.Errorf("W[bb] B[aa] should be merged with B[aa] W[bb]!")
+	}
+}
+
+// Tests analyzing the joseki dictionary
+func TestCursorTranspositionsJosekiDictionary(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping the joseki dictionary in short mode")
+	}
+
+	sgfData, _ := ioutil.ReadFile(TestgameKogo)
+	cursor, _ := NewCursor(sgfData)
+
+	transpositions, err := cursor.FindTranspositions(TranspositionOptions{SideToMove: true})
+	if err != nil || len(transpositions) == 0 {
+		t.Fatalf("Dictionary should contain transpositions but was %+v", err)
+	}
+
+	for _, transposition := range transpositions {
+		if len(transposition.Nodes) < 2 {
+			t.Errorf("Transposition should have at least two nodes but was %+v", transposition)
+		}
+	}
+}
+
+// Tests that passes returning to an earlier position are no transposition and don't create cycles
+func TestCursorTranspositionsPassPass(t *testing.T) {
+	cursor, _ := NewCursor([]byte("(;SZ[9];B[aa];W[];B[])"))
+	options := TranspositionOptions{SideToMove: true}
+
+	if transpositions, _ := cursor.FindTranspositions(options); len(transpositions) != 0 {
+		t.Errorf("Two passes should not be a transposition but were %+v", transpositions)
+	}
+
+	roots, _ := cursor.TranspositionDAG(options)
+	depth := 0
+	for position := roots[0]; len(position.Children) > 0; position = position.Children[0] {
+		if depth++; depth > 3 {
+			t.Fatalf("DAG should not contain a cycle!")
+		}
+	}
+
+	if depth != 2 || len(roots[0].Children[0].Nodes) != 2 {
+		t.Errorf("B[] should be merged into the position after B[aa] but depth was %d", depth)
+	}
+}
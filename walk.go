@@ -0,0 +1,159 @@
+package libaduk
+
+// Tells Walk how to continue after a node was visited
+type WalkAction uint8
+
+const (
+	WALK_CONTINUE      WalkAction = iota // Visit the children of the node
+	WALK_SKIP_CHILDREN                   // Continue without the children of the node
+	WALK_STOP                            // Stop the walk
+)
+
+// Iterates over nodes of a tree, use it like
+//
+//	for it := node.PreOrder(); it.Next(); {
+//		fmt.Println(it.Node().ToString(), it.Depth())
+//	}
+type NodeIterator struct {
+	advance func() (*Node, int)
+	node    *Node
+	depth   int
+}
+
+// Moves to the next node, returns false if there are no more nodes
+func (it *NodeIterator) Next() bool {
+	it.node, it.depth = it.advance()
+
+	return it.node != nil
+}
+
+// Returns the current node
+func (it *NodeIterator) Node() *Node {
+	return it.node
+}
+
+// Returns the depth of the current node, relative to the node the iteration started with
+func (it *NodeIterator) Depth() int {
+	return it.depth
+}
+
+// Returns an iterator over the node and all its descendants in depth-first pre-order, variations in their order
+func (node *Node) PreOrder() *NodeIterator {
+	var current *Node
+	depth := 0
+
+	return &NodeIterator{advance: func() (*Node, int) {
+		if current == nil {
+			current = node
+		} else {
+			current, depth = nextPreOrder(node, current, depth, true)
+		}
+
+		return current, depth
+	}}
+}
+
+// Returns an iterator over the node and all its descendants level by level
+func (node *Node) BreadthFirst() *NodeIterator {
+	queue := []*Node{node}
+	depths := []int{0}
+
+	return &NodeIterator{advance: func() (*Node, int) {
+		if len(queue) == 0 {
+			return nil, 0
+		}
+
+		current, depth := queue[0], depths[0]
+		queue, depths = queue[1:], depths[1:]
+
+		for child := current.Next; child != nil; child = child.Down {
+			queue = append(queue, child)
+			depths = append(depths, depth+1)
+		}
+
+		return current, depth
+	}}
+}
+
+// Returns an iterator over the node and its first children up to the end of the main line
+func (node *Node) MainLine() *NodeIterator {
+	var current *Node
+	depth := -1
+
+	return &NodeIterator{advance: func() (*Node, int) {
+		if depth < 0 {
+			current = node
+		} else if current != nil {
+			current = current.Next
+		}
+		depth++
+
+		return current, depth
+	}}
+}
+
+// Returns an iterator over all nodes without children below the node in depth-first order
+func (node *Node) Leaves() *NodeIterator {
+	preOrder := node.PreOrder()
+
+	return &NodeIterator{advance: func() (*Node, int) {
+		for preOrder.Next() {
+			if preOrder.Node().Next == nil {
+				return preOrder.Node(), preOrder.Depth()
+			}
+		}
+
+		return nil, 0
+	}}
+}
+
+// Returns an iterator over all nodes from the root to the node (including both)
+func (node *Node) PathFromRoot() *NodeIterator {
+	path := pathToNode(node)
+	i := -1
+
+	return &NodeIterator{advance: func() (*Node, int) {
+		i++
+		if i >= len(path) {
+			return nil, 0
+		}
+
+		return path[i], i
+	}}
+}
+
+// Visits the node and all its descendants in depth-first pre-order. The visitor gets every node with its depth
+// relative to node and decides if the children are visited or the walk stops. The walk is iterative, so deep
+// trees don't overflow the stack
+func Walk(node *Node, visit func(*Node, int) WalkAction) {
+	current, depth := node, 0
+
+	for current != nil {
+		action := visit(current, depth)
+		if action == WALK_STOP {
+			return
+		}
+
+		current, depth = nextPreOrder(node, current, depth, action == WALK_CONTINUE)
+	}
+}
+
+// Returns the node after current in pre-order below start and its depth. Children are skipped if descend is false.
+// If start is nil, the walk continues into the following games of the collection (their roots are linked by Down)
+func nextPreOrder(start *Node, current *Node, depth int, descend bool) (*Node, int) {
+	if descend && current.Next != nil {
+		return current.Next, depth + 1
+	}
+
+	// Go up until there is an unvisited sibling, the siblings of start don't belong to its tree
+	for current != start {
+		if current.Down != nil {
+			return current.Down, depth
+		}
+
+		current = current.Previous
+		depth--
+	}
+
+	return nil, 0
+}
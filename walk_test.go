@@ -0,0 +1,122 @@
+package libaduk
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// Returns the moves of all nodes of the iterator, the root is shown as "-"
+func iteratedMoves(it *NodeIterator) string {
+	moves := []string{}
+
+	for it.Next() {
+		move := it.Node().PropertyValue("B") + it.Node().PropertyValue("W")
+		if move == "" {
+			move = "-"
+		}
+		moves = append(moves, move)
+	}
+
+	return strings.Join(moves, " ")
+}
+
+// Tests the order of all iterators
+func TestNodeIterators(t *testing.T) {
+	cursor, _ := NewCursor([]byte(editTestGame))
+	root := cursor.Current()
+	ff := root.Next.Next.Down.Next.Down
+
+	tests := []struct {
+		name     string
+		it       *NodeIterator
+		expected string
+	}{
+		{"PreOrder", root.PreOrder(), "- aa bb cc dd ee ff gg"},
+		{"PreOrder of a variation", root.Next.Next.Down.PreOrder(), "dd ee ff"},
+		{"BreadthFirst", root.BreadthFirst(), "- aa bb dd gg cc ee ff"},
+		{"MainLine", root.MainLine(), "- aa bb cc"},
+		{"Leaves", root.Leaves(), "cc ee ff gg"},
+		{"PathFromRoot", ff.PathFromRoot(), "- aa dd ff"},
+	}
+
+	for _, test := range tests {
+		if moves := iteratedMoves(test.it); moves != test.expected {
+			t.Errorf("%s should return %s but was %s", test.name, test.expected, moves)
+		}
+	}
+
+	depth := -1
+	for it := root.PreOrder(); it.Next(); {
+		if it.Node() == ff {
+			depth = it.Depth()
+		}
+	}
+	if depth != 3 {
+		t.Errorf("Depth of ff should be 3 but was %d", depth)
+	}
+}
+
+// Tests skipping children and stopping the walk
+func TestWalk(t *testing.T) {
+	cursor, _ := NewCursor([]byte(editTestGame))
+	visited := []string{}
+
+	Walk(cursor.Current(), func(node *Node, depth int) WalkAction {
+		move := node.PropertyValue("B") + node.PropertyValue("W")
+		visited = append(visited, move)
+
+		switch move {
+		case "dd":
+			return WALK_SKIP_CHILDREN
+		case "gg":
+			return WALK_STOP
+		}
+		return WALK_CONTINUE
+	})
+
+	if strings.Join(visited, " ") != " aa bb cc dd gg" {
+		t.Errorf("Walk should skip the children of dd but visited %+v", visited)
+	}
+}
+
+// Tests walking very deep and large trees
+func TestWalkDeepTrees(t *testing.T) {
+	root := NewNode(nil)
+	last := root
+	for i := 0; i < 200000; i++ {
+		node := NewNode(last)
+		last.Next = node
+		last.numChildren = 1
+		last = node
+	}
+
+	deepest := 0
+	Walk(root, func(node *Node, depth int) WalkAction {
+		deepest = depth
+		return WALK_CONTINUE
+	})
+
+	if deepest != 200000 {
+		t.Errorf("Walk should reach depth 200000 but was %d", deepest)
+	}
+
+	sgfData, _ := ioutil.ReadFile(TestgameKogo)
+	cursor, _ := NewCursor(sgfData)
+
+	preOrder, breadthFirst, walked := 0, 0, 0
+	for it := cursor.Current().PreOrder(); it.Next(); {
+		preOrder++
+	}
+	for it := cursor.Current().BreadthFirst(); it.Next(); {
+		breadthFirst++
+	}
+	Walk(cursor.Current(), func(node *Node, depth int) WalkAction {
+		walked++
+		return WALK_CONTINUE
+	})
+
+	if preOrder < 10000 || preOrder != breadthFirst || preOrder != walked {
+		t.Errorf("All traversals should visit the same nodes but were %d, %d and %d", preOrder, breadthFirst, walked)
+	}
+}
@@ -2,34 +2,76 @@ package libaduk
 
 import (
 	"fmt"
-	"math/rand"
-	"time"
+	"sync"
 )
 
+// Seed of the tables used by NewZobristHash, hashes of all boards with the same size are comparable
+const DefaultZobristSeed int64 = 0x6c6962616475
+
 type ZobristHash struct {
-	table     [][]int64
-	hash      int64
+	table     [][2]uint64
+	hash      uint64
 	boardsize uint8
 }
 
-// Create a new Zobrist struct for given boardsize
-func NewZobristHash(boardSize uint8) *ZobristHash {
-	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
-	table := make([][]int64, boardSize*boardSize)
+// Identifies a shared zobrist table
+type zobristTableKey struct {
+	boardSize uint8
+	seed      int64
+}
 
-	for i, _ := range table {
-		table[i] = []int64{rnd.Int63(), rnd.Int63()}
-	}
+var (
+	zobristTables      = map[zobristTableKey][][2]uint64{}
+	zobristTablesMutex sync.Mutex
+)
 
+// Create a new Zobrist struct for given boardsize that uses the default table
+func NewZobristHash(boardSize uint8) *ZobristHash {
+	return NewZobristHashWithSeed(boardSize, DefaultZobristSeed)
+}
+
+// Create a new Zobrist struct for given boardsize with the table generated from seed.
+// Tables are shared, so every hash with the same size and seed has the same values for the same position
+func NewZobristHashWithSeed(boardSize uint8, seed int64) *ZobristHash {
 	return &ZobristHash{
-		table,
+		zobristTable(boardSize, seed),
 		0,
 		boardSize,
 	}
 }
 
+// Returns the shared table for the given boardsize and seed, it is generated on first use
+func zobristTable(boardSize uint8, seed int64) [][2]uint64 {
+	zobristTablesMutex.Lock()
+	defer zobristTablesMutex.Unlock()
+
+	key := zobristTableKey{boardSize, seed}
+	if table, ok := zobristTables[key]; ok {
+		return table
+	}
+
+	// splitmix64 generates the same numbers on every platform and Go version
+	state := uint64(seed)
+	next := func() uint64 {
+		state += 0x9e3779b97f4a7c15
+		z := state
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		return z ^ (z >> 31)
+	}
+
+	table := make([][2]uint64, int(boardSize)*int(boardSize))
+	for i := range table {
+		table[i] = [2]uint64{next(), next()}
+	}
+
+	zobristTables[key] = table
+
+	return table
+}
+
 // Update the hash for the played move
-func (zob *ZobristHash) Hash(x uint8, y uint8, status BoardStatus) (int64, error) {
+func (zob *ZobristHash) Hash(x uint8, y uint8, status BoardStatus) (uint64, error) {
 	var index int
 
 	if status == WHITE {
@@ -37,15 +79,15 @@ func (zob *ZobristHash) Hash(x uint8, y uint8, status BoardStatus) (int64, error
 	} else if status == BLACK {
 		index = 0
 	} else {
-		return -1, fmt.Errorf("The provided status (%d) is not valid!", status)
+		return 0, fmt.Errorf("The provided status (%d) is not valid!", status)
 	}
 
-	zob.hash ^= zob.table[zob.boardsize*x+y][index]
+	zob.hash ^= zob.table[int(zob.boardsize)*int(x)+int(y)][index]
 
 	return zob.hash, nil
 }
 
 // Returns the current hash value
-func (zob *ZobristHash) GetHash() int64 {
+func (zob *ZobristHash) GetHash() uint64 {
 	return zob.hash
 }
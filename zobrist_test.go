@@ -18,3 +18,38 @@ func TestBasicZobristHash(t *testing.T) {
 		t.Errorf("The second hash (%d) should be equal to the first (%d)", hashOne, hashTwo)
 	}
 }
+
+// Tests if boards with the same position have the same hash
+func TestZobristHashComparableAcrossBoards(t *testing.T) {
+	boardOne, _ := NewBoard(19)
+	boardTwo, _ := NewBoard(19)
+
+	boardOne.Play(3, 3, BLACK)
+	boardOne.Play(15, 15, WHITE)
+	boardTwo.Play(15, 15, WHITE)
+	boardTwo.Play(3, 3, BLACK)
+
+	if boardOne.GetHash() != boardTwo.GetHash() || boardOne.GetHash() == 0 {
+		t.Errorf("Same positions should have the same hash but were %d and %d", boardOne.GetHash(), boardTwo.GetHash())
+	}
+}
+
+// Tests if seeds create stable and different tables
+func TestZobristHashWithSeed(t *testing.T) {
+	one := NewZobristHashWithSeed(9, 1)
+	two := NewZobristHashWithSeed(9, 1)
+	other := NewZobristHashWithSeed(9, 2)
+
+	hashOne, _ := one.Hash(4, 4, BLACK)
+	hashTwo, _ := two.Hash(4, 4, BLACK)
+	hashOther, _ := other.Hash(4, 4, BLACK)
+
+	if hashOne != hashTwo || hashOne == hashOther {
+		t.Errorf("Same seeds should give the same hashes (%d, %d) and other seeds different ones (%d)", hashOne, hashTwo, hashOther)
+	}
+
+	// The default table is fixed, so stored hashes stay valid between runs
+	if hash, _ := NewZobristHash(19).Hash(3, 3, BLACK); hash != 0xb64a5a8be8f96b5c {
+		t.Errorf("Default table changed, hash of (3, 3) was %#x", hash)
+	}
+}